@@ -12,15 +12,20 @@
 // You should have received a copy of the GNU Affero General Public License
 // along with this program. If not, see <https://www.gnu.org/licenses/>.
 
-
 package main
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"log"
+	"net"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -29,6 +34,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"golang.org/x/term"
@@ -45,6 +51,11 @@ var (
 	height     int
 	numThreads int
 	showHelp   bool
+	mute       bool
+	volume     float64
+	serveAddr  string
+	renderer   string
+	hwaccel    string
 )
 
 // --- Global Playback State & Config ---
@@ -66,6 +77,19 @@ var (
 	renderedFrames    [][]byte
 	lastRenderedFrame = -1
 	userAction        = ""
+
+	// Audio playback
+	audioMutex   sync.Mutex
+	audioCancel  context.CancelFunc
+	audioCmd     *exec.Cmd
+	playbackCtx  context.Context
+	playbackPath string
+
+	// Thumbnail scrubber and keyframe-aware seeking, populated once per
+	// video by playVideo and read by handleInput/playbackLoop under
+	// stateMutex.
+	scrubberThumbs  []string
+	keyframeIndices []int
 )
 
 const seekSeconds = 5
@@ -121,9 +145,92 @@ func formatTime(frameIndex int, frameRate int) string {
 	return fmt.Sprintf("%02d:%02d", minutes, seconds)
 }
 
+// stopAudio cancels and releases any audio process currently playing,
+// without waiting for it; the process group dies on its own context.
+func stopAudio() {
+	audioMutex.Lock()
+	defer audioMutex.Unlock()
+	if audioCancel != nil {
+		audioCancel()
+		audioCancel = nil
+		audioCmd = nil
+	}
+}
+
+// atempoChain expands speed into a chain of ffmpeg atempo filter stages,
+// since atempo only accepts factors in [0.5, 2.0] natively; speeds outside
+// that range (e.g. the 0.25x preset) are built from repeated 0.5x/2.0x
+// stages instead of clamping, so audio doesn't drift out of sync with video
+// over a long playback.
+func atempoChain(speed float64) string {
+	var stages []string
+	for speed < 0.5 {
+		stages = append(stages, "atempo=0.5")
+		speed /= 0.5
+	}
+	for speed > 2.0 {
+		stages = append(stages, "atempo=2.0")
+		speed /= 2.0
+	}
+	stages = append(stages, fmt.Sprintf("atempo=%.3f", speed))
+	return strings.Join(stages, ",")
+}
+
+// startAudio stops any previously running audio process and, unless mute is
+// set, spawns a fresh one starting at startSeconds into path and played back
+// at speed. ffmpeg decodes to raw PCM and applies atempo for speed changes;
+// its stdout is piped straight into aplay's stdin so no PCM ever touches
+// disk. Callers hold no lock across this; it's cheap to call on every
+// pause/resume/seek/speed change since it just replaces the pipeline.
+func startAudio(ctx context.Context, path string, startSeconds, speed float64) {
+	stopAudio()
+	if mute {
+		return
+	}
+
+	audioCtx, cancel := context.WithCancel(ctx)
+
+	ffmpegArgs := []string{
+		"-nostdin", "-hide_banner", "-loglevel", "error",
+		"-ss", fmt.Sprintf("%.3f", startSeconds),
+		"-i", path,
+		"-vn", "-af", fmt.Sprintf("%s,volume=%.2f", atempoChain(speed), volume),
+		"-f", "s16le", "-ar", "48000", "-ac", "2", "pipe:1",
+	}
+	ffmpegCmd := exec.CommandContext(audioCtx, "ffmpeg", ffmpegArgs...)
+	pcmOut, err := ffmpegCmd.StdoutPipe()
+	if err != nil {
+		cancel()
+		return
+	}
+
+	aplayCmd := exec.CommandContext(audioCtx, "aplay", "-q", "-f", "S16_LE", "-r", "48000", "-c", "2")
+	aplayCmd.Stdin = pcmOut
+
+	if err := ffmpegCmd.Start(); err != nil {
+		cancel()
+		return
+	}
+	if err := aplayCmd.Start(); err != nil {
+		ffmpegCmd.Process.Kill()
+		cancel()
+		return
+	}
+
+	audioMutex.Lock()
+	audioCancel = cancel
+	audioCmd = aplayCmd
+	audioMutex.Unlock()
+
+	go func() {
+		ffmpegCmd.Wait()
+		aplayCmd.Wait()
+	}()
+}
+
 // handleInput processes keyboard events for playback control.
 func handleInput(cancel context.CancelFunc) {
-	buf := make([]byte, 3)
+	buf := make([]byte, 6)
 	for {
 		// Check if the input handler should terminate
 		stateMutex.Lock()
@@ -139,19 +246,32 @@ func handleInput(cancel context.CancelFunc) {
 		}
 
 		stateMutex.Lock()
+		audioDirty := false
 		switch {
 		case n == 1 && (buf[0] == 'q' || buf[0] == 3): // 'q' or Ctrl+C
 			userAction = "quit"
 			cancel()
 		case n == 1 && buf[0] == ' ':
 			isPaused = !isPaused
+			audioDirty = true
 		case n == 1 && buf[0] == '+': // Increase speed
 			if currentSpeedMultiplierIndex < len(playbackSpeedMultipliers)-1 {
 				currentSpeedMultiplierIndex++
+				audioDirty = true
 			}
 		case n == 1 && buf[0] == '-': // Decrease speed
 			if currentSpeedMultiplierIndex > 0 {
 				currentSpeedMultiplierIndex--
+				audioDirty = true
+			}
+		case n == 6 && buf[0] == '\x1b' && buf[1] == '[' && buf[2] == '1' && buf[3] == ';' && buf[4] == '2': // Shift+Arrow: keyframe seek
+			switch buf[5] {
+			case 'C': // Shift+Right: next keyframe
+				currentFrameIndex = nearestKeyframe(keyframeIndices, currentFrameIndex, true)
+				audioDirty = true
+			case 'D': // Shift+Left: previous keyframe
+				currentFrameIndex = nearestKeyframe(keyframeIndices, currentFrameIndex, false)
+				audioDirty = true
 			}
 		case n == 3 && buf[0] == '\x1b' && buf[1] == '[': // Arrow keys
 			switch buf[2] {
@@ -166,15 +286,154 @@ func handleInput(cancel context.CancelFunc) {
 				if totalFrames > 0 && currentFrameIndex >= totalFrames {
 					currentFrameIndex = totalFrames - 1
 				}
+				audioDirty = true
 			case 'D': // Left Arrow: Seek backward
 				currentFrameIndex -= seekAmountInFrames
 				if currentFrameIndex < 0 {
 					currentFrameIndex = 0
 				}
+				audioDirty = true
 			}
 		}
+		frameIdx := currentFrameIndex
+		speed := playbackSpeedMultipliers[currentSpeedMultiplierIndex]
+		paused := isPaused
+		ctx := playbackCtx
+		path := playbackPath
 		stateMutex.Unlock()
+
+		if audioDirty && ctx != nil {
+			if paused {
+				stopAudio()
+			} else {
+				startAudio(ctx, path, float64(frameIdx)/float64(fps), speed)
+			}
+		}
+	}
+}
+
+const scrubberThumbCount = 20
+
+// buildThumbnailStrip runs a single one-shot ffmpeg pass extracting
+// scrubberThumbCount evenly spaced, low-res thumbnails from path and renders
+// each through chafa as a single small cell, for use as a seek-bar
+// scrubber. Returns nil if duration is unknown or the pass fails, in which
+// case playbackLoop simply skips drawing a scrubber line.
+func buildThumbnailStrip(ctx context.Context, path string, duration float64) []string {
+	if duration <= 0 {
+		return nil
+	}
+
+	tempDir, err := os.MkdirTemp("", "hitvid-thumbs-*")
+	if err != nil {
+		return nil
+	}
+	defer os.RemoveAll(tempDir)
+
+	thumbPattern := filepath.Join(tempDir, "thumb-%03d.jpg")
+	ffmpegArgs := []string{
+		"-nostdin", "-hide_banner", "-loglevel", "warning",
+		"-i", path,
+		"-vf", fmt.Sprintf("fps=%d/%f,scale=32:-1", scrubberThumbCount, duration),
+		"-q:v", "4", "-vframes", strconv.Itoa(scrubberThumbCount), thumbPattern,
+	}
+	if err := exec.CommandContext(ctx, "ffmpeg", ffmpegArgs...).Run(); err != nil {
+		log.Printf("Warning: could not build thumbnail strip for %s: %v\r\n", path, err)
+		return nil
+	}
+
+	var thumbs []string
+	for i := 1; i <= scrubberThumbCount; i++ {
+		thumbPath := filepath.Join(tempDir, fmt.Sprintf("thumb-%03d.jpg", i))
+		if _, err := os.Stat(thumbPath); err != nil {
+			break
+		}
+		chafaCmd := exec.CommandContext(ctx, "chafa", "--size", "1x1", "--symbols", "block", "--colors", colors, "--dither", "none", thumbPath)
+		cell, err := chafaCmd.Output()
+		if err != nil {
+			continue
+		}
+		thumbs = append(thumbs, strings.TrimRight(string(cell), "\n"))
+	}
+	return thumbs
+}
+
+// drawScrubber renders the thumbnail strip on the line below the info line,
+// with the cell nearest currentFrame shown in inverse video.
+func drawScrubber(termH int, thumbs []string, currentFrame, total int) {
+	if len(thumbs) == 0 {
+		return
+	}
+	current := 0
+	if total > 0 {
+		current = currentFrame * len(thumbs) / total
+		if current >= len(thumbs) {
+			current = len(thumbs) - 1
+		}
+	}
+	var sb strings.Builder
+	for i, thumb := range thumbs {
+		if i == current {
+			sb.WriteString("\x1b[7m")
+			sb.WriteString(thumb)
+			sb.WriteString("\x1b[27m")
+		} else {
+			sb.WriteString(thumb)
+		}
+	}
+	fmt.Printf("\x1b[%d;1H\x1b[K%s", termH+2, sb.String())
+}
+
+// loadKeyframes runs ffprobe once to collect path's keyframe presentation
+// timestamps, converting each to a frame index at the configured fps so
+// Shift+Left/Right can snap to them for instant seeks that don't have to
+// wait for the renderer to catch up.
+func loadKeyframes(ctx context.Context, path string) []int {
+	cmd := exec.CommandContext(ctx, "ffprobe",
+		"-v", "error", "-select_streams", "v:0", "-skip_frame", "nokey",
+		"-show_entries", "frame=pts_time", "-of", "csv=p=0", path)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		log.Printf("Warning: could not probe keyframes for %s: %v\r\n", path, err)
+		return nil
+	}
+
+	var frames []int
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		pts, err := strconv.ParseFloat(line, 64)
+		if err != nil {
+			continue
+		}
+		frames = append(frames, int(pts*float64(fps)))
+	}
+	return frames
+}
+
+// nearestKeyframe returns the keyframe immediately after current (forward)
+// or immediately before it (backward). current is returned unchanged if
+// there is no keyframe in that direction.
+func nearestKeyframe(keyframes []int, current int, forward bool) int {
+	if forward {
+		for _, k := range keyframes {
+			if k > current {
+				return k
+			}
+		}
+		return current
+	}
+	best := current
+	for _, k := range keyframes {
+		if k >= current {
+			break
+		}
+		best = k
 	}
+	return best
 }
 
 // playVideo handles the entire lifecycle of playing one video.
@@ -189,7 +448,10 @@ func playVideo(ctx context.Context, path string) string {
 	renderedFrames = make([][]byte, 0, 2048)
 	lastRenderedFrame = -1
 	userAction = "" // Clear previous action
+	playbackCtx = ctx
+	playbackPath = path
 	stateMutex.Unlock()
+	defer stopAudio()
 
 	// --- Get video info ---
 	videoDuration, err := getVideoDuration(ctx, path)
@@ -201,20 +463,233 @@ func playVideo(ctx context.Context, path string) string {
 		stateMutex.Unlock()
 	}
 
-	// --- Setup temp dir ---
-	tempDir, err := os.MkdirTemp("", "hitvid-go-*")
-	if err != nil {
-		log.Fatalf("Failed to create temp directory: %v", err)
+	thumbs := buildThumbnailStrip(ctx, path, videoDuration)
+	keyframes := loadKeyframes(ctx, path)
+	stateMutex.Lock()
+	scrubberThumbs = thumbs
+	keyframeIndices = keyframes
+	stateMutex.Unlock()
+
+	startAudio(ctx, path, 0, playbackSpeedMultipliers[currentSpeedMultiplierIndex])
+
+	waitExtraction := startExtraction(ctx, path)
+
+	// --- Playback Loop ---
+	playbackLoop(ctx)
+
+	waitExtraction()
+
+	stateMutex.Lock()
+	finalAction := userAction
+	stateMutex.Unlock()
+
+	if finalAction != "" {
+		return finalAction
 	}
-	defer os.RemoveAll(tempDir)
-	jpgDir := filepath.Join(tempDir, "jpg_frames")
-	os.Mkdir(jpgDir, 0755)
+	return "finished"
+}
 
-	// --- Setup rendering pipeline ---
-	type renderJob struct {
-		index   int
-		jpgPath string
+// resolveRenderer turns "auto" into a concrete renderer name by checking
+// $TERM_PROGRAM and $TERM for known pixel-graphics terminals, falling back
+// to plain chafa symbols when nothing more specific is detected.
+func resolveRenderer() string {
+	if renderer != "auto" {
+		return renderer
+	}
+	switch os.Getenv("TERM_PROGRAM") {
+	case "iTerm.app":
+		return "iterm"
+	case "WezTerm":
+		return "sixel"
+	}
+	if strings.Contains(os.Getenv("TERM"), "kitty") {
+		return "kitty"
+	}
+	return "chafa"
+}
+
+const kittyChunkSize = 4096
+
+// encodeKittyFrame base64-encodes jpeg into a chunked Kitty graphics-protocol
+// APC transmit-and-display command, sized to width x height terminal cells.
+// The image placement id is frameIndex%65536+1, the same id playbackLoop
+// computes to delete it once the next frame is on screen.
+func encodeKittyFrame(jpeg []byte, frameIndex, width, height int) []byte {
+	encoded := base64.StdEncoding.EncodeToString(jpeg)
+
+	var buf bytes.Buffer
+	for len(encoded) > 0 {
+		chunk := encoded
+		if len(chunk) > kittyChunkSize {
+			chunk = encoded[:kittyChunkSize]
+		}
+		encoded = encoded[len(chunk):]
+		more := 0
+		if len(encoded) > 0 {
+			more = 1
+		}
+		if buf.Len() == 0 {
+			fmt.Fprintf(&buf, "\x1b_Ga=T,f=100,t=d,i=%d,c=%d,r=%d,m=%d,q=2,z=-1;%s\x1b\\", frameIndex%65536+1, width, height, more, chunk)
+		} else {
+			fmt.Fprintf(&buf, "\x1b_Gm=%d;%s\x1b\\", more, chunk)
+		}
+	}
+	return buf.Bytes()
+}
+
+// kittyDeleteImage returns the APC sequence that deletes a previously drawn
+// Kitty graphics placement by id.
+func kittyDeleteImage(id int) string {
+	return fmt.Sprintf("\x1b_Ga=d,d=I,i=%d\x1b\\", id)
+}
+
+// encodeIterm2Frame wraps jpeg in iTerm2's inline-image escape sequence,
+// sized to width x height terminal cells.
+func encodeIterm2Frame(jpeg []byte, width, height int) []byte {
+	encoded := base64.StdEncoding.EncodeToString(jpeg)
+	return []byte(fmt.Sprintf("\x1b]1337;File=inline=1;width=%d;height=%d:%s\a", width, height, encoded))
+}
+
+// renderJPEG rasterizes jpeg using the active renderer: "sixel" and the
+// default "chafa" both shell out to chafa (which natively supports a sixel
+// output format); "kitty" and "iterm" embed the JPEG directly as a
+// protocol-specific escape sequence instead, bypassing chafa's
+// character/sixel quantization entirely.
+func renderJPEG(ctx context.Context, jpeg []byte, frameIndex int) ([]byte, error) {
+	switch resolveRenderer() {
+	case "kitty":
+		return encodeKittyFrame(jpeg, frameIndex, width, height), nil
+	case "iterm":
+		return encodeIterm2Frame(jpeg, width, height), nil
+	default:
+		extra := []string{"--symbols", symbols}
+		if resolveRenderer() == "sixel" {
+			extra = []string{"-f", "sixel"}
+		}
+		chafaArgs := append([]string{"--size", fmt.Sprintf("%dx%d", width, height), "--colors", colors, "--dither", dither}, extra...)
+		chafaArgs = append(chafaArgs, "-")
+		chafaCmd := exec.CommandContext(ctx, "chafa", chafaArgs...)
+		chafaCmd.Stdin = bytes.NewReader(jpeg)
+		output, err := chafaCmd.Output()
+		if err != nil {
+			return nil, err
+		}
+		if runtime.GOOS != "windows" {
+			output = bytes.ReplaceAll(output, []byte("\n"), []byte("\r\n"))
+		}
+		return output, nil
 	}
+}
+
+var (
+	hwAccelOnce     sync.Once
+	resolvedHWAccel string
+	hwRenderDevice  string
+
+	hwAccelListOnce sync.Once
+	hwAccelSupport  map[string]bool
+)
+
+// resolveHWAccel turns "auto" into a concrete hardware-decode mode by probing
+// the platform for the most likely available accelerator, falling back to
+// "none" (plain software decode) when nothing is detected. The probe (glob
+// and PATH lookups) only runs once per process; the result is cached and
+// reused by the per-frame status line.
+func resolveHWAccel() string {
+	hwAccelOnce.Do(func() {
+		resolvedHWAccel, hwRenderDevice = probeHWAccel()
+	})
+	return resolvedHWAccel
+}
+
+// ffmpegHWAccels runs `ffmpeg -hwaccels` once and returns the set of accel
+// names the installed ffmpeg build actually reports, so auto-detection never
+// selects a mode (e.g. vaapi from a /dev/dri glob) that this ffmpeg can't
+// actually use. An empty/unreadable result means no accel is considered
+// supported, which auto-detection treats the same as nothing being present.
+func ffmpegHWAccels() map[string]bool {
+	hwAccelListOnce.Do(func() {
+		hwAccelSupport = make(map[string]bool)
+		out, err := exec.Command("ffmpeg", "-hide_banner", "-hwaccels").Output()
+		if err != nil {
+			return
+		}
+		for _, line := range strings.Split(string(out), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasSuffix(line, ":") {
+				continue
+			}
+			hwAccelSupport[line] = true
+		}
+	})
+	return hwAccelSupport
+}
+
+func probeHWAccel() (mode, renderDevice string) {
+	if hwaccel != "auto" {
+		return hwaccel, "/dev/dri/renderD128"
+	}
+	supported := ffmpegHWAccels()
+	switch runtime.GOOS {
+	case "darwin":
+		if supported["videotoolbox"] {
+			return "videotoolbox", ""
+		}
+	case "linux":
+		matches, _ := filepath.Glob("/dev/dri/renderD*")
+		haveNvidia := false
+		if _, err := exec.LookPath("nvidia-smi"); err == nil {
+			haveNvidia = true
+		}
+		if haveNvidia && supported["cuda"] {
+			return "nvdec", ""
+		}
+		if len(matches) > 0 && supported["vaapi"] {
+			return "vaapi", matches[0]
+		}
+	}
+	return "none", ""
+}
+
+// hwaccelArgs returns the ffmpeg decode-side flags to insert before -i for
+// mode, and the hwdownload/format filter that runExtractionAttempt puts at the
+// front of the -vf chain so the decoded frames land back in normal CPU memory
+// where chafa can read them. (nil, "") is returned for "none" or an
+// unrecognized mode.
+func hwaccelArgs(mode string) (preInput []string, vfSuffix string) {
+	switch mode {
+	case "vaapi":
+		device := hwRenderDevice
+		if device == "" {
+			device = "/dev/dri/renderD128"
+		}
+		return []string{"-hwaccel", "vaapi", "-hwaccel_device", device, "-hwaccel_output_format", "vaapi"}, "hwdownload,format=nv12"
+	case "nvdec":
+		return []string{"-hwaccel", "cuda", "-hwaccel_output_format", "cuda"}, "hwdownload,format=nv12"
+	case "videotoolbox":
+		return []string{"-hwaccel", "videotoolbox"}, "hwdownload,format=nv12"
+	case "qsv":
+		return []string{"-hwaccel", "qsv", "-hwaccel_output_format", "qsv"}, "hwdownload,format=nv12"
+	default:
+		return nil, ""
+	}
+}
+
+type renderJob struct {
+	index int
+	jpeg  []byte
+}
+
+// startExtraction launches the chafa render worker pool and a single ffmpeg
+// process that decodes path straight to an image2pipe/mjpeg stream on its
+// stdout, populating the shared renderedFrames/frameReadyCond/
+// extractionComplete state that playbackLoop (and the -serve chunk
+// handlers) read from. There is no jpgDir and no per-frame disk I/O: frames
+// are scanned out of ffmpeg's stdout and piped straight into each chafa
+// worker's stdin. It returns a function that blocks until every dispatched
+// render job has finished; callers should defer or otherwise invoke it once
+// they're done consuming frames.
+func startExtraction(ctx context.Context, path string) func() {
 	var wgRender sync.WaitGroup
 	jobs := make(chan renderJob, 100)
 	for i := 0; i < numThreads; i++ {
@@ -222,18 +697,13 @@ func playVideo(ctx context.Context, path string) string {
 		go func() {
 			defer wgRender.Done()
 			for job := range jobs {
-				chafaArgs := []string{"--size", fmt.Sprintf("%dx%d", width, height), "--symbols", symbols, "--colors", colors, "--dither", dither, job.jpgPath}
-				chafaCmd := exec.CommandContext(ctx, "chafa", chafaArgs...)
-				output, err := chafaCmd.Output()
+				output, err := renderJPEG(ctx, job.jpeg, job.index)
 				if err != nil {
 					if ctx.Err() == nil {
-						log.Printf("chafa failed for %s: %v\r\n", job.jpgPath, err)
+						log.Printf("render failed for frame %d: %v\r\n", job.index, err)
 					}
 					output = nil
 				}
-				if runtime.GOOS != "windows" && output != nil {
-					output = bytes.ReplaceAll(output, []byte("\n"), []byte("\r\n"))
-				}
 				// CRITICAL FIX: This section is now simplified to remove the faulty conditional check.
 				// It now guarantees a broadcast for every job received, fixing the deadlock.
 				stateMutex.Lock()
@@ -245,67 +715,117 @@ func playVideo(ctx context.Context, path string) string {
 		}()
 	}
 
-	// --- Start dispatcher and ffmpeg ---
 	go func() {
-		dispatchedFrameIndex := 0
-		for {
-			if ctx.Err() != nil {
-				break
-			}
-			framePath := filepath.Join(jpgDir, fmt.Sprintf("frame-%05d.jpg", dispatchedFrameIndex+1))
-			if _, err := os.Stat(framePath); err == nil {
-				stateMutex.Lock()
-				if len(renderedFrames) <= dispatchedFrameIndex {
-					renderedFrames = append(renderedFrames, nil)
-				}
-				stateMutex.Unlock()
-				jobs <- renderJob{index: dispatchedFrameIndex, jpgPath: framePath}
-				dispatchedFrameIndex++
-			} else {
-				stateMutex.Lock()
-				isDone := extractionComplete
-				stateMutex.Unlock()
-				if isDone {
-					break
-				}
-				time.Sleep(10 * time.Millisecond)
-			}
+		mode := resolveHWAccel()
+		dispatched := runExtractionAttempt(ctx, path, jobs, mode)
+		if dispatched == 0 && mode != "none" && ctx.Err() == nil {
+			log.Printf("hardware decode (%s) produced no frames, retrying with software decode\r\n", mode)
+			runExtractionAttempt(ctx, path, jobs, "none")
 		}
 		close(jobs)
+
+		stateMutex.Lock()
+		extractionComplete = true
+		frameReadyCond.Broadcast()
+		stateMutex.Unlock()
 	}()
 
+	return func() {
+		wgRender.Wait()
+	}
+}
+
+// runExtractionAttempt runs a single ffmpeg decode of path using the given
+// hwaccel mode, scanning its stdout for frames and dispatching each one to
+// jobs. It returns the number of frames dispatched, so startExtraction can
+// tell a hardware decode that silently produced nothing apart from one that
+// genuinely reached end of stream, and fall back to software decode.
+func runExtractionAttempt(ctx context.Context, path string, jobs chan<- renderJob, mode string) int {
 	ffmpegVF := fmt.Sprintf("fps=%d,scale='min(iw,%d)':-1", fps, width*8)
-	ffmpegArgs := []string{"-nostdin", "-hide_banner", "-loglevel", "warning", "-i", path, "-vf", ffmpegVF, "-q:v", "2", filepath.Join(jpgDir, "frame-%05d.jpg")}
+	hwPreInput, hwVF := hwaccelArgs(mode)
+	if hwVF != "" {
+		ffmpegVF = hwVF + "," + ffmpegVF
+	}
+	ffmpegArgs := append([]string{"-nostdin", "-hide_banner", "-loglevel", "warning"}, hwPreInput...)
+	ffmpegArgs = append(ffmpegArgs,
+		"-i", path, "-vf", ffmpegVF,
+		"-f", "image2pipe", "-vcodec", "mjpeg", "-q:v", "2", "pipe:1",
+	)
 	ffmpegCmd := exec.CommandContext(ctx, "ffmpeg", ffmpegArgs...)
 	var ffmpegErr bytes.Buffer
 	ffmpegCmd.Stderr = &ffmpegErr
+	stdout, err := ffmpegCmd.StdoutPipe()
+	if err != nil {
+		log.Printf("failed to open ffmpeg stdout (hwaccel=%s): %v\r\n", mode, err)
+		return 0
+	}
 	if err := ffmpegCmd.Start(); err != nil {
-		log.Fatalf("Failed to start ffmpeg: %v", err)
+		log.Printf("failed to start ffmpeg (hwaccel=%s): %v\r\n", mode, err)
+		return 0
 	}
-	go func() {
-		ffmpegCmd.Wait()
+
+	dispatched := 0
+	scanJPEGFrames(stdout, func(jpeg []byte) {
 		stateMutex.Lock()
-		extractionComplete = true
-		frameReadyCond.Broadcast()
+		index := len(renderedFrames)
+		renderedFrames = append(renderedFrames, nil)
 		stateMutex.Unlock()
-	}()
+		jobs <- renderJob{index: index, jpeg: jpeg}
+		dispatched++
+	})
 
-	// --- Playback Loop ---
-	playbackLoop(ctx)
-
-	wgRender.Wait()
+	if err := ffmpegCmd.Wait(); err != nil && ctx.Err() == nil {
+		log.Printf("ffmpeg extraction failed (hwaccel=%s): %v\r\n%s", mode, err, ffmpegErr.String())
+	}
+	return dispatched
+}
 
-	stateMutex.Lock()
-	finalAction := userAction
-	stateMutex.Unlock()
+const (
+	jpegSOI = "\xff\xd8"
+	jpegEOI = "\xff\xd9"
+)
 
-	if finalAction != "" {
-		return finalAction
+// scanJPEGFrames reads r in chunks, looking for back-to-back JPEG images
+// (SOI..EOI), and invokes fn with each complete frame's bytes as soon as
+// it's found. It trims each frame out of its working buffer immediately
+// after, so memory stays proportional to one frame rather than the whole
+// stream.
+func scanJPEGFrames(r io.Reader, fn func(jpeg []byte)) {
+	var buf bytes.Buffer
+	chunk := make([]byte, 64*1024)
+	for {
+		n, readErr := r.Read(chunk)
+		if n > 0 {
+			buf.Write(chunk[:n])
+			for {
+				data := buf.Bytes()
+				start := bytes.Index(data, []byte(jpegSOI))
+				if start < 0 {
+					buf.Reset()
+					break
+				}
+				end := bytes.Index(data[start:], []byte(jpegEOI))
+				if end < 0 {
+					if start > 0 {
+						buf.Next(start)
+					}
+					break
+				}
+				end += start + len(jpegEOI)
+				frame := make([]byte, end-start)
+				copy(frame, data[start:end])
+				fn(frame)
+				buf.Next(end)
+			}
+		}
+		if readErr != nil {
+			return
+		}
 	}
-	return "finished"
 }
 
 func playbackLoop(ctx context.Context) {
+	lastKittyPlacement := -1
 	for {
 		stateMutex.Lock()
 		// Check for exit conditions first
@@ -350,9 +870,23 @@ func playbackLoop(ctx context.Context) {
 			continue
 		}
 
-		fmt.Print("\x1b[H")
+		activeRenderer := resolveRenderer()
+		if activeRenderer == "kitty" || activeRenderer == "iterm" || activeRenderer == "sixel" {
+			fmt.Print("\x1b[H\x1b[2J")
+		} else {
+			fmt.Print("\x1b[H")
+		}
+		if activeRenderer == "kitty" {
+			if lastKittyPlacement >= 0 {
+				fmt.Print(kittyDeleteImage(lastKittyPlacement))
+			}
+			lastKittyPlacement = frameIdx%65536 + 1
+		}
 		fmt.Print(string(content))
 		printInfo("PLAYING", frameIdx, height, fps, speed)
+		stateMutex.Lock()
+		drawScrubber(height, scrubberThumbs, frameIdx, totalFrames)
+		stateMutex.Unlock()
 
 		frameDelay := time.Duration(float64(time.Second) / (float64(fps) * speed))
 		elapsed := time.Since(frameStartTime)
@@ -363,6 +897,260 @@ func playbackLoop(ctx context.Context) {
 	}
 }
 
+// --- HTTP/TCP streaming server mode ---
+
+// chunkFrameCount is the number of rendered frames bundled into a single
+// HTTP chunk; at the default 15fps that's roughly one second per chunk.
+const chunkFrameCount = 15
+
+const serverIdleTimeout = 60 * time.Second
+
+// runServer turns hitvid into a small streaming server for path instead of
+// an interactive terminal player: one HTTP endpoint serves an M3U8-style
+// playlist of numbered chunks, each chunk endpoint serves a gzipped run of
+// rendered frames (plus a JSON sidecar of per-frame durations), and a raw
+// TCP listener blasts the same ANSI stream to any client that connects. The
+// extraction/render pipeline underneath is the same startExtraction used by
+// local playback. The whole pipeline tears down after serverIdleTimeout
+// with no HTTP request or TCP connection.
+func runServer(ctx context.Context, addr, path string) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stateMutex.Lock()
+	currentFrameIndex = 0
+	totalFrames = 0
+	extractionComplete = false
+	renderedFrames = make([][]byte, 0, 2048)
+	lastRenderedFrame = -1
+	stateMutex.Unlock()
+
+	if videoDuration, err := getVideoDuration(ctx, path); err != nil {
+		log.Printf("Warning: Could not get video duration for %s: %v\r\n", path, err)
+	} else {
+		stateMutex.Lock()
+		totalFrames = int(videoDuration * float64(fps))
+		stateMutex.Unlock()
+	}
+
+	waitExtraction := startExtraction(ctx, path)
+	defer waitExtraction()
+
+	var lastActivity int64
+	touch := func() { atomic.StoreInt64(&lastActivity, time.Now().UnixNano()) }
+	touch()
+
+	go func() {
+		ticker := time.NewTicker(5 * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				idleFor := time.Since(time.Unix(0, atomic.LoadInt64(&lastActivity)))
+				if idleFor > serverIdleTimeout {
+					log.Printf("No activity for %s, shutting down stream server\r\n", serverIdleTimeout)
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	// waitForFrame blocks until frame n has been rendered, extraction
+	// finishes without reaching it, or ctx is cancelled.
+	waitForFrame := func(n int) ([]byte, bool) {
+		stateMutex.Lock()
+		defer stateMutex.Unlock()
+		for lastRenderedFrame < n && ctx.Err() == nil {
+			if extractionComplete && n >= len(renderedFrames) {
+				return nil, false
+			}
+			frameReadyCond.Wait()
+		}
+		if ctx.Err() != nil || n >= len(renderedFrames) {
+			return nil, false
+		}
+		return renderedFrames[n], true
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/playlist.m3u8", func(w http.ResponseWriter, r *http.Request) {
+		touch()
+		stateMutex.Lock()
+		total := totalFrames
+		stateMutex.Unlock()
+
+		numChunks := 0
+		if total > 0 {
+			numChunks = (total + chunkFrameCount - 1) / chunkFrameCount
+		}
+		w.Header().Set("Content-Type", "application/vnd.apple.mpegurl")
+		fmt.Fprintln(w, "#EXTM3U")
+		fmt.Fprintln(w, "#EXT-X-VERSION:3")
+		fmt.Fprintf(w, "#EXT-X-TARGETDURATION:%d\n", (chunkFrameCount+fps-1)/fps)
+		if numChunks > 0 {
+			for i := 0; i < numChunks; i++ {
+				fmt.Fprintf(w, "#EXTINF:%.3f,\n", float64(chunkFrameCount)/float64(fps))
+				fmt.Fprintf(w, "/chunk/%d\n", i)
+			}
+			fmt.Fprintln(w, "#EXT-X-ENDLIST")
+		} else {
+			// Duration unknown (still probing); advertise a handful of
+			// chunks and let the player re-fetch the playlist as it goes.
+			for i := 0; i < 10; i++ {
+				fmt.Fprintf(w, "#EXTINF:%.3f,\n", float64(chunkFrameCount)/float64(fps))
+				fmt.Fprintf(w, "/chunk/%d\n", i)
+			}
+		}
+	})
+
+	mux.HandleFunc("/chunk/", func(w http.ResponseWriter, r *http.Request) {
+		touch()
+		idxStr := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/chunk/"), ".json")
+		chunkIdx, err := strconv.Atoi(idxStr)
+		if err != nil || chunkIdx < 0 {
+			http.Error(w, "invalid chunk index", http.StatusBadRequest)
+			return
+		}
+		start := chunkIdx * chunkFrameCount
+		durations := make([]float64, 0, chunkFrameCount)
+
+		if strings.HasSuffix(r.URL.Path, ".json") {
+			for i := 0; i < chunkFrameCount; i++ {
+				if _, ok := waitForFrame(start + i); !ok {
+					break
+				}
+				touch()
+				durations = append(durations, 1/float64(fps))
+			}
+			if len(durations) == 0 {
+				http.NotFound(w, r)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"chunk":        chunkIdx,
+				"frameCount":   len(durations),
+				"durationsSec": durations,
+			})
+			return
+		}
+
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		frames := 0
+		for i := 0; i < chunkFrameCount; i++ {
+			content, ok := waitForFrame(start + i)
+			if !ok {
+				break
+			}
+			touch()
+			gz.Write([]byte("\x1b[H\x1b[2J"))
+			gz.Write(content)
+			frames++
+		}
+		gz.Close()
+
+		if frames == 0 {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(buf.Bytes())
+	})
+
+	server := &http.Server{Addr: addr, Handler: mux}
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+
+	go runTelnetServer(ctx, addr, touch)
+
+	log.Printf("Serving %s on http://%s (playlist.m3u8) and as a raw ANSI stream\r\n", path, addr)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return fmt.Errorf("stream server failed: %w", err)
+	}
+	return nil
+}
+
+// runTelnetServer listens one port above httpAddr and streams the rendered
+// ANSI frames, in order and paced to fps, to every client that connects --
+// a bare-bones "telnet and watch" endpoint alongside the HTTP chunk API.
+func runTelnetServer(ctx context.Context, httpAddr string, touch func()) {
+	host, portStr, err := net.SplitHostPort(httpAddr)
+	if err != nil {
+		log.Printf("telnet server disabled: invalid address %q: %v\r\n", httpAddr, err)
+		return
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		log.Printf("telnet server disabled: invalid port %q: %v\r\n", portStr, err)
+		return
+	}
+	telnetAddr := net.JoinHostPort(host, strconv.Itoa(port+1))
+
+	lc := net.ListenConfig{}
+	ln, err := lc.Listen(ctx, "tcp", telnetAddr)
+	if err != nil {
+		log.Printf("telnet server disabled: %v\r\n", err)
+		return
+	}
+	defer ln.Close()
+	go func() {
+		<-ctx.Done()
+		ln.Close()
+	}()
+
+	log.Printf("Raw ANSI stream available via telnet %s\r\n", telnetAddr)
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		touch()
+		go streamToConn(ctx, conn, touch)
+	}
+}
+
+// streamToConn writes every rendered frame to conn, in order, paced to fps,
+// starting from frame 0 regardless of how far local playback has gotten.
+// touch is called on every frame so a client that's simply watching, with no
+// other traffic, doesn't trip the idle monitor mid-video.
+func streamToConn(ctx context.Context, conn net.Conn, touch func()) {
+	defer conn.Close()
+	frameDelay := time.Second / time.Duration(fps)
+	for i := 0; ; i++ {
+		stateMutex.Lock()
+		for lastRenderedFrame < i && ctx.Err() == nil {
+			if extractionComplete && i >= len(renderedFrames) {
+				stateMutex.Unlock()
+				return
+			}
+			frameReadyCond.Wait()
+		}
+		if ctx.Err() != nil || i >= len(renderedFrames) {
+			stateMutex.Unlock()
+			return
+		}
+		content := renderedFrames[i]
+		stateMutex.Unlock()
+		touch()
+
+		if _, err := conn.Write([]byte("\x1b[H\x1b[2J")); err != nil {
+			return
+		}
+		if _, err := conn.Write(content); err != nil {
+			return
+		}
+		time.Sleep(frameDelay)
+	}
+}
+
 func printHelp() {
 	fmt.Println(`
         hitvid v1.1.3 - High-performance terminal video player
@@ -395,9 +1183,22 @@ func printHelp() {
             -w <integer>
                Render width. (Default: terminal width)
             -h <integer>
-               Render height. (Default: terminal height - 1)
+               Render height. (Default: terminal height - 2, to leave room for the info and scrubber lines)
             -threads <integer>
                Number of parallel threads to use for rendering. (Default: 4)
+            -mute
+               Disable audio playback.
+            -volume <float>
+               Audio volume multiplier. (Default: 1.0)
+            -serve <addr>
+               Run as a streaming server instead of an interactive player, e.g. -serve :8080.
+               Serves an HLS-style playlist/chunk API over HTTP and a raw ANSI
+               stream over telnet on the next port up.
+            -renderer <string>
+               Graphics renderer: auto, chafa, sixel, kitty, iterm. (Default: "auto")
+            -hwaccel <string>
+               Hardware-accelerated decode: auto, none, vaapi, nvdec, videotoolbox, qsv.
+               (Default: "auto"). The active mode (if any) is shown in the status line.
             -help, -help
                Display this help message and exit.
 
@@ -408,6 +1209,8 @@ func printHelp() {
             - : Decrease playback speed.
             → (right arrow) : Jump forward 5 seconds.
             ← (left arrow) : Jump back 5 seconds.
+            Shift + → : Snap forward to the next keyframe.
+            Shift + ← : Snap backward to the previous keyframe.
             ↑ (up arrow) : Previous video in the playlist.
             ↓ (Down arrow): Next video in the playlist.
         `)
@@ -420,8 +1223,13 @@ func main() {
 	flag.StringVar(&colors, "colors", "256", "Color mode")
 	flag.StringVar(&dither, "dither", "ordered", "Dithering mode")
 	flag.IntVar(&width, "w", 0, "Display width (default: terminal width)")
-	flag.IntVar(&height, "h", 0, "Display height (default: terminal height - 1)")
+	flag.IntVar(&height, "h", 0, "Display height (default: terminal height - 2, to leave room for the info and scrubber lines)")
 	flag.IntVar(&numThreads, "threads", 4, "Number of parallel threads for Chafa rendering")
+	flag.BoolVar(&mute, "mute", false, "Disable audio playback")
+	flag.Float64Var(&volume, "volume", 1.0, "Audio volume multiplier")
+	flag.StringVar(&serveAddr, "serve", "", "Run as a streaming server on this address (e.g. :8080) instead of an interactive player")
+	flag.StringVar(&renderer, "renderer", "auto", "Graphics renderer: auto, chafa, sixel, kitty, iterm")
+	flag.StringVar(&hwaccel, "hwaccel", "auto", "Hardware-accelerated decode: auto, none, vaapi, nvdec, videotoolbox, qsv")
 	flag.BoolVar(&showHelp, "help", false, "Show detailed program description")
 
 	flag.Usage = func() {
@@ -448,9 +1256,31 @@ func main() {
 		}
 	}
 
+	switch renderer {
+	case "auto", "chafa", "sixel", "kitty", "iterm":
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown -renderer %q (want auto, chafa, sixel, kitty, or iterm).\n", renderer)
+		os.Exit(1)
+	}
+
 	seekAmountInFrames = seekSeconds * fps
 	frameReadyCond = sync.NewCond(&stateMutex)
 
+	if serveAddr != "" {
+		if width == 0 {
+			width = 80
+		}
+		if height == 0 {
+			height = 24
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		if err := runServer(ctx, serveAddr, videoPath); err != nil {
+			log.Fatalf("Server failed: %v", err)
+		}
+		return
+	}
+
 	playlist, err := getPlaylist(filepath.Dir(videoPath))
 	if err != nil || len(playlist) == 0 {
 		log.Fatalf("Failed to find any videos in the directory: %v", err)
@@ -477,7 +1307,7 @@ func main() {
 		width = termWidth
 	}
 	if height == 0 {
-		height = termHeight - 1
+		height = termHeight - 2
 	}
 
 	oldState, termErr := term.MakeRaw(int(os.Stdin.Fd()))
@@ -563,7 +1393,11 @@ func printInfoUnlocked(status string, currentFrame, termH, frameRate int, speed
 	var info string
 	switch status {
 	case "PLAYING", "PAUSED":
-		info = fmt.Sprintf("[%s] %s / %s | Speed: %.2fx | %s", status, currentTimeStr, totalTimeStr, speed, controls)
+		if accel := resolveHWAccel(); accel != "none" {
+			info = fmt.Sprintf("[%s] %s / %s | Speed: %.2fx | HW: %s | %s", status, currentTimeStr, totalTimeStr, speed, accel, controls)
+		} else {
+			info = fmt.Sprintf("[%s] %s / %s | Speed: %.2fx | %s", status, currentTimeStr, totalTimeStr, speed, controls)
+		}
 	case "BUFFERING":
 		info = fmt.Sprintf("[%s] %s / %s...", status, currentTimeStr, totalTimeStr)
 	case "FINISHED":
@@ -0,0 +1,213 @@
+// Package cache provides a two-tier frame cache: a bounded in-memory LRU
+// backed by a bounded on-disk LRU, so long preload/stream/live sessions stop
+// growing /dev/shm without bound.
+package cache
+
+import (
+	"container/list"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// entry is the bookkeeping kept per frame in each tier's LRU list.
+type entry struct {
+	frame int
+	size  int64
+}
+
+// Cache is a frame cache with an in-memory tier (recently/soon to be played
+// frames) and a disk tier (everything else that's been rendered but evicted
+// from memory), each with its own byte budget. The package defines no
+// FrameCache interface; callers use *Cache directly.
+type Cache struct {
+	diskDir string
+
+	memMu     sync.Mutex
+	memOrder  *list.List
+	memElems  map[int]*list.Element
+	memData   map[int][]byte
+	memBytes  int64
+	memBudget int64
+
+	diskMu     sync.Mutex
+	diskOrder  *list.List
+	diskElems  map[int]*list.Element
+	diskBytes  int64
+	diskBudget int64
+}
+
+// New returns a Cache that keeps rendered frames under diskDir, spilling
+// memBudgetMB of them in memory and diskBudgetMB of the rest on disk before
+// evicting the oldest.
+func New(diskDir string, memBudgetMB, diskBudgetMB int) *Cache {
+	return &Cache{
+		diskDir:    diskDir,
+		memOrder:   list.New(),
+		memElems:   make(map[int]*list.Element),
+		memData:    make(map[int][]byte),
+		memBudget:  int64(memBudgetMB) * 1024 * 1024,
+		diskOrder:  list.New(),
+		diskElems:  make(map[int]*list.Element),
+		diskBudget: int64(diskBudgetMB) * 1024 * 1024,
+	}
+}
+
+func (c *Cache) diskPath(frame int) string {
+	return filepath.Join(c.diskDir, fmt.Sprintf("frame-%05d.cache", frame))
+}
+
+// Put stores a rendered frame, landing it in the memory tier and evicting
+// down to budget (spilling evictees to the disk tier, which evicts its own
+// oldest entries down to its budget).
+func (c *Cache) Put(frame int, data []byte) error {
+	c.memMu.Lock()
+	if el, ok := c.memElems[frame]; ok {
+		c.memOrder.MoveToFront(el)
+		c.memBytes -= int64(len(c.memData[frame]))
+		c.memData[frame] = data
+		c.memBytes += int64(len(data))
+		el.Value = entry{frame: frame, size: int64(len(data))}
+	} else {
+		el := c.memOrder.PushFront(entry{frame: frame, size: int64(len(data))})
+		c.memElems[frame] = el
+		c.memData[frame] = data
+		c.memBytes += int64(len(data))
+	}
+
+	for c.memBytes > c.memBudget && c.memOrder.Len() > 1 {
+		back := c.memOrder.Back()
+		e := back.Value.(entry)
+		c.memOrder.Remove(back)
+		delete(c.memElems, e.frame)
+		spillData := c.memData[e.frame]
+		delete(c.memData, e.frame)
+		c.memBytes -= e.size
+		if err := c.writeDisk(e.frame, spillData); err != nil {
+			c.memMu.Unlock()
+			return err
+		}
+	}
+	c.memMu.Unlock()
+	return nil
+}
+
+// Get returns a rendered frame, checking memory first, then disk (promoting
+// a disk hit back into memory).
+func (c *Cache) Get(frame int) ([]byte, bool) {
+	c.memMu.Lock()
+	if el, ok := c.memElems[frame]; ok {
+		c.memOrder.MoveToFront(el)
+		data := c.memData[frame]
+		c.memMu.Unlock()
+		return data, true
+	}
+	c.memMu.Unlock()
+
+	data, ok := c.readDisk(frame)
+	if !ok {
+		return nil, false
+	}
+	c.promote(frame, data)
+	return data, true
+}
+
+// promote moves a disk-tier hit back into the memory tier.
+func (c *Cache) promote(frame int, data []byte) {
+	c.memMu.Lock()
+	if _, ok := c.memElems[frame]; !ok {
+		el := c.memOrder.PushFront(entry{frame: frame, size: int64(len(data))})
+		c.memElems[frame] = el
+		c.memData[frame] = data
+		c.memBytes += int64(len(data))
+		for c.memBytes > c.memBudget && c.memOrder.Len() > 1 {
+			back := c.memOrder.Back()
+			e := back.Value.(entry)
+			c.memOrder.Remove(back)
+			delete(c.memElems, e.frame)
+			spillData := c.memData[e.frame]
+			delete(c.memData, e.frame)
+			c.memBytes -= e.size
+			c.writeDisk(e.frame, spillData)
+		}
+	}
+	c.memMu.Unlock()
+}
+
+func (c *Cache) writeDisk(frame int, data []byte) error {
+	if err := os.WriteFile(c.diskPath(frame), data, 0644); err != nil {
+		return fmt.Errorf("frame cache: failed to spill frame %d to disk: %w", frame, err)
+	}
+
+	c.diskMu.Lock()
+	defer c.diskMu.Unlock()
+	if el, ok := c.diskElems[frame]; ok {
+		c.diskOrder.MoveToFront(el)
+		c.diskBytes += int64(len(data)) - el.Value.(entry).size
+		el.Value = entry{frame: frame, size: int64(len(data))}
+	} else {
+		el := c.diskOrder.PushFront(entry{frame: frame, size: int64(len(data))})
+		c.diskElems[frame] = el
+		c.diskBytes += int64(len(data))
+	}
+
+	for c.diskBytes > c.diskBudget && c.diskOrder.Len() > 1 {
+		back := c.diskOrder.Back()
+		e := back.Value.(entry)
+		c.diskOrder.Remove(back)
+		delete(c.diskElems, e.frame)
+		c.diskBytes -= e.size
+		os.Remove(c.diskPath(e.frame))
+	}
+	return nil
+}
+
+func (c *Cache) readDisk(frame int) ([]byte, bool) {
+	c.diskMu.Lock()
+	el, ok := c.diskElems[frame]
+	if ok {
+		c.diskOrder.MoveToFront(el)
+	}
+	c.diskMu.Unlock()
+	if !ok {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(c.diskPath(frame))
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// Has reports whether frame is available in either tier, without promoting
+// or otherwise mutating LRU order.
+func (c *Cache) Has(frame int) bool {
+	c.memMu.Lock()
+	_, inMem := c.memElems[frame]
+	c.memMu.Unlock()
+	if inMem {
+		return true
+	}
+	c.diskMu.Lock()
+	_, onDisk := c.diskElems[frame]
+	c.diskMu.Unlock()
+	return onDisk
+}
+
+// Warm promotes frame into the memory tier if it's only on disk, for use by
+// a prefetcher that wants upcoming frames hot before playback reaches them.
+func (c *Cache) Warm(frame int) {
+	c.memMu.Lock()
+	if el, ok := c.memElems[frame]; ok {
+		c.memOrder.MoveToFront(el)
+		c.memMu.Unlock()
+		return
+	}
+	c.memMu.Unlock()
+
+	if data, ok := c.readDisk(frame); ok {
+		c.promote(frame, data)
+	}
+}
@@ -0,0 +1,288 @@
+// Package pipeline decodes a video and renders it to ANSI text without ever
+// writing per-frame JPEG or .txt files to disk.
+//
+// The ideal version of this reads frames straight out of libav via cgo
+// bindings (astiav/goav) and rasterizes them through libchafa in-process.
+// Neither is vendored in this tree, so Run still shells out to a single
+// ffmpeg process per video -- but it reads decoded frames off ffmpeg's
+// stdout pipe instead of a tempdir, and hands each one to a worker pool over
+// a channel instead of polling the filesystem. That removes the per-frame
+// JPEG files of the old jpgDir/chafaFramesDir pipeline, and rendered frames
+// go to the bounded frame cache rather than one .txt file each. One chafa
+// process is still spawned per frame.
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"sync"
+
+	"github.com/Hitmux/hitvid/internal/cache"
+)
+
+// Frame is a single rendered frame, ready to print to the terminal.
+type Frame struct {
+	Index    int
+	Rendered []byte
+}
+
+// FrameStatus reports the outcome of a Store.Get call.
+type FrameStatus int
+
+const (
+	// FrameReady means rendered holds the frame's bytes.
+	FrameReady FrameStatus = iota
+	// FrameEvicted means frame n was rendered at some point but has since
+	// aged out of the bounded cache (e.g. a seek landed behind the render
+	// window). It will never come back, but later frames may still be
+	// fine, so callers should skip ahead rather than treat the whole
+	// pipeline as finished.
+	FrameEvicted
+	// FrameGone means the pipeline closed (or ctx was cancelled) before
+	// frame n was ever rendered.
+	FrameGone
+)
+
+// Store tracks which frames have been rendered and lets readers block until
+// a given frame becomes available. The rendered bytes themselves live in a
+// cache.Cache rather than an ever-growing map, so a multi-hour preload or
+// stream session stays bounded the same way the legacy tempdir path does,
+// instead of keeping every frame in process memory forever.
+//
+// Get's caller also acts as the playhead: Run's producer calls WaitForRoom
+// before dispatching frame n for rendering, so decoding can't race
+// arbitrarily far ahead of playback and push the frame about to be needed
+// out of the cache before it's been watched.
+type Store struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	cache    *cache.Cache
+	ready    map[int]bool
+	playhead int
+	done     bool
+	err      error
+}
+
+// NewStore returns an empty, ready-to-use Store backed by c.
+func NewStore(c *cache.Cache) *Store {
+	s := &Store{cache: c, ready: make(map[int]bool)}
+	s.cond = sync.NewCond(&s.mu)
+	return s
+}
+
+// Put records the rendered bytes for frame n and wakes any waiters.
+func (s *Store) Put(n int, rendered []byte) error {
+	if err := s.cache.Put(n, rendered); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.ready[n] = true
+	s.cond.Broadcast()
+	s.mu.Unlock()
+	return nil
+}
+
+// Close marks extraction as finished; pending Get calls for frames that will
+// never arrive return immediately instead of blocking forever.
+func (s *Store) Close(err error) {
+	s.mu.Lock()
+	s.done = true
+	s.err = err
+	s.cond.Broadcast()
+	s.mu.Unlock()
+}
+
+// Get blocks until frame n is rendered, the pipeline closes, or ctx is
+// cancelled. It also records n as the current playhead, which WaitForRoom
+// uses to throttle how far the renderer gets ahead of playback.
+func (s *Store) Get(ctx context.Context, n int) (rendered []byte, status FrameStatus) {
+	s.mu.Lock()
+	if n > s.playhead {
+		s.playhead = n
+		s.cond.Broadcast()
+	}
+	for {
+		if s.ready[n] {
+			s.mu.Unlock()
+			if data, hit := s.cache.Get(n); hit {
+				return data, FrameReady
+			}
+			return nil, FrameEvicted
+		}
+		if s.done {
+			s.mu.Unlock()
+			return nil, FrameGone
+		}
+		if ctx.Err() != nil {
+			s.mu.Unlock()
+			return nil, FrameGone
+		}
+		s.cond.Wait()
+	}
+}
+
+// WaitForRoom blocks until frame index is within ahead frames of the
+// current playhead (see Get), the pipeline closes, or ctx is cancelled. Run
+// calls it before dispatching each decoded frame so a fast decoder can't run
+// unboundedly far past the playhead, which is what would otherwise guarantee
+// the cache evicts a frame before it's ever played.
+func (s *Store) WaitForRoom(ctx context.Context, index, ahead int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for index-s.playhead > ahead {
+		if s.done {
+			return false
+		}
+		if ctx.Err() != nil {
+			return false
+		}
+		s.cond.Wait()
+	}
+	return true
+}
+
+// Err returns the error extraction finished with, if any.
+func (s *Store) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+const (
+	jpegSOI = "\xff\xd8"
+	jpegEOI = "\xff\xd9"
+)
+
+// RenderFunc rasterizes a single JPEG-encoded frame into its final output
+// bytes (e.g. by piping it into chafa's stdin). index is the frame's 1-based
+// position in the stream, passed through so protocols that need a stable
+// per-frame placement id (e.g. Kitty graphics) can derive one.
+type RenderFunc func(ctx context.Context, index int, jpeg []byte) ([]byte, error)
+
+// Run decodes videoPath with a single ffmpeg image2pipe/mjpeg process, scans
+// its stdout for JPEG frame boundaries, and fans each frame out to
+// numWorkers goroutines that call render and store the result in s. Before
+// dispatching each frame it calls s.WaitForRoom to stay within renderAhead
+// frames of the playhead, so decoding doesn't race to EOF and evict frames
+// playback hasn't reached yet. Run blocks until ffmpeg exits or ctx is
+// cancelled.
+func Run(ctx context.Context, videoPath, vf string, numWorkers, renderAhead int, render RenderFunc, s *Store) error {
+	args := []string{
+		"-nostdin", "-loglevel", "error",
+		"-i", videoPath,
+		"-vf", vf,
+		"-f", "image2pipe", "-vcodec", "mjpeg", "-q:v", "2",
+		"pipe:1",
+	}
+	cmd := exec.CommandContext(ctx, "ffmpeg", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to open ffmpeg stdout: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("ffmpeg failed to start: %w", err)
+	}
+
+	type job struct {
+		index int
+		jpeg  []byte
+	}
+	jobs := make(chan job, numWorkers*2)
+
+	var wg sync.WaitGroup
+	for i := 0; i < numWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				out, err := render(ctx, j.index, j.jpeg)
+				if err != nil {
+					if ctx.Err() == nil {
+						s.Close(err)
+					}
+					continue
+				}
+				if err := s.Put(j.index, out); err != nil && ctx.Err() == nil {
+					s.Close(err)
+				}
+			}
+		}()
+	}
+
+	scanErr := scanJPEGFrames(stdout, func(index int, frame []byte) {
+		if !s.WaitForRoom(ctx, index, renderAhead) {
+			return
+		}
+		jobs <- job{index: index, jpeg: frame}
+	})
+	close(jobs)
+	wg.Wait()
+
+	waitErr := cmd.Wait()
+	if ctx.Err() != nil {
+		s.Close(ctx.Err())
+		return ctx.Err()
+	}
+	if scanErr != nil {
+		s.Close(scanErr)
+		return scanErr
+	}
+	if waitErr != nil {
+		err := fmt.Errorf("ffmpeg execution failed: %w\n%s", waitErr, stderr.String())
+		s.Close(err)
+		return err
+	}
+	s.Close(nil)
+	return nil
+}
+
+// scanJPEGFrames reads r in chunks, looking for back-to-back JPEG images
+// (SOI..EOI), and invokes fn with a 1-based index for each one found. Unlike
+// buffering the whole stream, it trims each frame out of its working buffer
+// as soon as it's found, so memory stays proportional to one frame, not the
+// whole video.
+func scanJPEGFrames(r io.Reader, fn func(index int, frame []byte)) error {
+	var buf bytes.Buffer
+	chunk := make([]byte, 64*1024)
+	index := 0
+
+	for {
+		n, readErr := r.Read(chunk)
+		if n > 0 {
+			buf.Write(chunk[:n])
+			for {
+				data := buf.Bytes()
+				start := bytes.Index(data, []byte(jpegSOI))
+				if start < 0 {
+					buf.Reset()
+					break
+				}
+				end := bytes.Index(data[start:], []byte(jpegEOI))
+				if end < 0 {
+					if start > 0 {
+						buf.Next(start)
+					}
+					break
+				}
+				end += start + len(jpegEOI)
+				index++
+				frame := make([]byte, end-start)
+				copy(frame, data[start:end])
+				fn(index, frame)
+				buf.Next(end)
+			}
+		}
+		if readErr != nil {
+			if readErr == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("reading ffmpeg stdout: %w", readErr)
+		}
+	}
+}
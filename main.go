@@ -4,42 +4,61 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"encoding/base64"
 	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"math"
 	"os"
 	"os/exec"
 	"os/signal"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/schollz/progressbar/v3"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/term"
+
+	"github.com/Hitmux/hitvid/internal/cache"
+	"github.com/Hitmux/hitvid/internal/pipeline"
 )
 
 var version = "1.1.0 (Go port)"
 
 type config struct {
-	VideoPath   string
-	FPS         float64
-	ScaleMode   string
-	Colors      string
-	Dither      string
-	Symbols     string
-	Width       int
-	Height      int
-	PlayMode    string
-	NumThreads  int
-	Quiet       bool
-	Loop        bool
-	SeekSeconds int
+	VideoPath     string
+	FPS           float64
+	ScaleMode     string
+	Colors        string
+	Dither        string
+	Symbols       string
+	Width         int
+	Height        int
+	PlayMode      string
+	NumThreads    int
+	Quiet         bool
+	Loop          bool
+	SeekSeconds   int
+	LiveBufFrames int
+	Legacy        bool
+	Adaptive      bool
+	MinFPS        float64
+	MaxFPS        float64
+	SceneThresh   float64
+	ScenesCSV     string
+	Protocol      string
+	Audio         bool
+	CacheMemMB    int
+	CacheDiskMB   int
 }
 
 type videoInfo struct {
@@ -49,6 +68,10 @@ type videoInfo struct {
 	AvgFrameRate   float64
 	TotalFrames    int
 	NbFramesStream int
+	IsLive         bool
+	// FrameTimestamps holds frame N's presentation time in seconds, indexed
+	// frame-1, when -adaptive sampling is in effect. Empty otherwise.
+	FrameTimestamps []float64
 }
 
 type playerState struct {
@@ -64,12 +87,28 @@ var (
 	tempDir        string
 	jpgFramesDir   string
 	chafaFramesDir string
+
+	// liveHeadFrame is the number of the frame most recently rendered in
+	// live mode; playFrames treats it as the playback write head.
+	liveHeadFrame int64
+
+	// frameCache holds rendered frames for every play mode, tiered between
+	// memory and chafaFramesDir so long preload/stream/live sessions don't
+	// grow /dev/shm (or, for the pipeline path, process memory) without
+	// bound.
+	frameCache *cache.Cache
 )
 
 const (
 	charPixelWidthApprox  = 8
 	charPixelHeightApprox = 16
 	maxFpsCap             = 60
+
+	// pipelineRenderAheadSeconds bounds how far runPipelinePreload/
+	// runPipelineStream let decoding run ahead of the playhead. Without it,
+	// ffmpeg decodes straight to EOF and the bounded frameCache evicts the
+	// frame playback is about to need long before it gets there.
+	pipelineRenderAheadSeconds = 20
 )
 
 func main() {
@@ -78,11 +117,19 @@ func main() {
 
 	cfg := parseAndValidateFlags()
 
-	if err := checkDependencies("ffmpeg", "ffprobe", "chafa", "stty"); err != nil {
+	requiredDeps := []string{"ffmpeg", "ffprobe", "chafa", "stty"}
+	if cfg.Audio {
+		requiredDeps = append(requiredDeps, "aplay")
+	}
+	if err := checkDependencies(requiredDeps...); err != nil {
 		fmt.Fprintln(os.Stderr, "Error:", err)
 		os.Exit(1)
 	}
 
+	if cfg.Protocol == "auto" {
+		cfg.Protocol = detectProtocol()
+	}
+
 	var err error
 	tempDir, err = setupTempDir()
 	if err != nil {
@@ -91,12 +138,22 @@ func main() {
 	}
 	defer cleanup()
 
-	vInfo, err := getVideoInfo(ctx, cfg.VideoPath, cfg.FPS)
+	frameCache = cache.New(chafaFramesDir, cfg.CacheMemMB, cfg.CacheDiskMB)
+
+	vInfo, err := getVideoInfo(ctx, cfg.VideoPath, cfg.FPS, cfg.PlayMode == "live")
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "Error getting video info:", err)
 		os.Exit(1)
 	}
 
+	if cfg.Adaptive {
+		vInfo, err = planAdaptiveSampling(ctx, cfg, vInfo)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error planning adaptive sampling:", err)
+			os.Exit(1)
+		}
+	}
+
 	if !cfg.Quiet {
 		fmt.Printf("Mode: %s, FPS: %.2f, Size: %dx%d, Threads: %d, File: %s\n",
 			cfg.PlayMode, cfg.FPS, cfg.Width, cfg.Height, cfg.NumThreads, cfg.VideoPath)
@@ -107,9 +164,21 @@ func main() {
 
 	switch cfg.PlayMode {
 	case "preload":
-		runPreload(gCtx, eg, cfg, vInfo)
+		if cfg.Legacy {
+			runPreload(gCtx, eg, cfg, vInfo)
+		} else {
+			runPipelinePreload(gCtx, eg, cfg, vInfo)
+		}
 	case "stream":
-		runStream(gCtx, eg, cfg, vInfo)
+		if cfg.Legacy {
+			runStream(gCtx, eg, cfg, vInfo)
+		} else {
+			runPipelineStream(gCtx, eg, cfg, vInfo)
+		}
+	case "live":
+		// The ring-buffer eviction in live mode targets the on-disk
+		// tempdir directly, so live playback always uses the legacy path.
+		runLive(gCtx, eg, cfg, vInfo)
 	default:
 		fmt.Fprintf(os.Stderr, "Invalid play mode: %s\n", cfg.PlayMode)
 		os.Exit(1)
@@ -142,11 +211,22 @@ func parseAndValidateFlags() config {
 	flag.StringVar(&cfg.Symbols, "symbols", "block", "Set symbol set: block, ascii, space")
 	flag.IntVar(&cfg.Width, "width", termWidth, "Set display width (default: terminal width)")
 	flag.IntVar(&cfg.Height, "height", termHeight-2, "Set display height (default: terminal height - 2)")
-	flag.StringVar(&cfg.PlayMode, "mode", "stream", "Playback mode: preload, stream")
+	flag.StringVar(&cfg.PlayMode, "mode", "stream", "Playback mode: preload, stream, live")
 	flag.IntVar(&cfg.NumThreads, "threads", runtime.NumCPU(), "Number of parallel threads for Chafa rendering")
 	flag.BoolVar(&cfg.Quiet, "quiet", false, "Suppress loading progress bars")
 	flag.BoolVar(&cfg.Loop, "loop", false, "Loop playback")
 	flag.IntVar(&cfg.SeekSeconds, "seek", 5, "Seconds to seek forward/backward")
+	flag.IntVar(&cfg.LiveBufFrames, "live-buffer", 150, "Ring buffer size (frames) kept on disk in live mode")
+	flag.BoolVar(&cfg.Legacy, "legacy", false, "Use the disk-based JPEG+txt extraction pipeline instead of the in-memory one")
+	flag.BoolVar(&cfg.Adaptive, "adaptive", false, "Sample frames densely at scene cuts and sparsely during static shots, instead of a fixed -fps")
+	flag.Float64Var(&cfg.MinFPS, "min-fps", 2, "Frames per second to sample during static shots (-adaptive only)")
+	flag.Float64Var(&cfg.MaxFPS, "max-fps", 24, "Frames per second to sample within 0.5s of a scene cut (-adaptive only)")
+	flag.Float64Var(&cfg.SceneThresh, "scene-threshold", 0.4, "ffmpeg scene-change score (0-1) that counts as a cut (-adaptive only)")
+	flag.StringVar(&cfg.ScenesCSV, "scenes-csv", "", "Path to a CSV of precomputed scene-cut timestamps (seconds), skips scene detection")
+	flag.StringVar(&cfg.Protocol, "protocol", "auto", "Graphics protocol: auto, chafa, sixel, kitty, iterm2")
+	flag.BoolVar(&cfg.Audio, "audio", false, "Play audio alongside the video, with playback paced by the audio clock")
+	flag.IntVar(&cfg.CacheMemMB, "cache-mem-mb", 256, "Max MB of rendered frames to keep in memory")
+	flag.IntVar(&cfg.CacheDiskMB, "cache-disk-mb", 2048, "Max MB of rendered frames to keep on disk once evicted from memory")
 	flag.BoolVar(&showHelp, "help", false, "Show this help message")
 	flag.BoolVar(&showHelp, "h", false, "Show this help message (shorthand)")
 
@@ -175,10 +255,13 @@ func parseAndValidateFlags() config {
 		os.Exit(0)
 	}
 
-	if _, err := os.Stat(cfg.VideoPath); os.IsNotExist(err) && !strings.HasPrefix(cfg.VideoPath, "http") {
+	if _, err := os.Stat(cfg.VideoPath); os.IsNotExist(err) && !isNetworkSource(cfg.VideoPath) {
 		fmt.Fprintf(os.Stderr, "Error: Video file '%s' not found.\n", cfg.VideoPath)
 		os.Exit(1)
 	}
+	if cfg.PlayMode == "live" && !isLiveManifest(cfg.VideoPath) && !isNetworkSource(cfg.VideoPath) {
+		fmt.Fprintln(os.Stderr, "Warning: -mode live expects an HLS (.m3u8), DASH (.mpd), or network URL source.")
+	}
 	if cfg.FPS <= 0 {
 		fmt.Fprintln(os.Stderr, "Error: FPS must be a positive number.")
 		os.Exit(1)
@@ -188,9 +271,42 @@ func parseAndValidateFlags() config {
 		cfg.FPS = maxFpsCap
 	}
 
+	switch cfg.Protocol {
+	case "auto", "chafa", "sixel", "kitty", "iterm2":
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown -protocol %q (want auto, chafa, sixel, kitty, or iterm2).\n", cfg.Protocol)
+		os.Exit(1)
+	}
+
+	if cfg.Adaptive {
+		if cfg.PlayMode == "live" {
+			fmt.Fprintln(os.Stderr, "Error: -adaptive requires a known-length source and can't be combined with -mode live.")
+			os.Exit(1)
+		}
+		if cfg.MinFPS <= 0 || cfg.MaxFPS <= 0 || cfg.MinFPS > cfg.MaxFPS {
+			fmt.Fprintln(os.Stderr, "Error: -min-fps must be positive and no greater than -max-fps.")
+			os.Exit(1)
+		}
+		// Adaptive sampling seeks one ffmpeg frame at a time onto disk; it
+		// doesn't go through the in-memory pipeline.
+		cfg.Legacy = true
+	}
+
 	return cfg
 }
 
+// isNetworkSource reports whether videoPath names a remote resource (HTTP(S),
+// RTMP, UDP, ...) rather than a local file.
+func isNetworkSource(videoPath string) bool {
+	return strings.Contains(videoPath, "://")
+}
+
+// isLiveManifest reports whether videoPath looks like an HLS or DASH manifest.
+func isLiveManifest(videoPath string) bool {
+	lower := strings.ToLower(videoPath)
+	return strings.HasSuffix(lower, ".m3u8") || strings.HasSuffix(lower, ".mpd")
+}
+
 func checkDependencies(cmds ...string) error {
 	for _, cmd := range cmds {
 		if _, err := exec.LookPath(cmd); err != nil {
@@ -245,6 +361,73 @@ func stty(args ...string) {
 	_ = cmd.Run()
 }
 
+// detectProtocol picks the best available graphics protocol for the current
+// terminal when -protocol is "auto": first from well-known env vars, then
+// (for xterm-like terminals that might support sixel) from the terminal's
+// own CSI primary-device-attributes response.
+func detectProtocol() string {
+	switch strings.ToLower(os.Getenv("TERM_PROGRAM")) {
+	case "iterm.app":
+		return "iterm2"
+	case "wezterm":
+		return "kitty"
+	}
+
+	term := strings.ToLower(os.Getenv("TERM"))
+	if strings.Contains(term, "kitty") || os.Getenv("KITTY_WINDOW_ID") != "" {
+		return "kitty"
+	}
+
+	if resp, err := queryDeviceAttributes(); err == nil && sixelInDeviceAttributes(resp) {
+		return "sixel"
+	}
+
+	return "chafa"
+}
+
+// queryDeviceAttributes sends the CSI Primary Device Attributes query and
+// returns whatever the terminal answers with, within a short timeout.
+func queryDeviceAttributes() (string, error) {
+	oldState, err := term.MakeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		return "", err
+	}
+	defer term.Restore(int(os.Stdin.Fd()), oldState)
+
+	fmt.Print("\x1b[c")
+
+	type result struct {
+		s   string
+		err error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		buf := make([]byte, 64)
+		n, err := os.Stdin.Read(buf)
+		ch <- result{string(buf[:n]), err}
+	}()
+
+	select {
+	case r := <-ch:
+		return r.s, r.err
+	case <-time.After(200 * time.Millisecond):
+		return "", errors.New("no response to device-attributes query")
+	}
+}
+
+// sixelInDeviceAttributes reports whether a DA1 response (ESC [ ? Pm c)
+// lists parameter 4, which xterm and its descendants use to advertise
+// sixel graphics support.
+func sixelInDeviceAttributes(resp string) bool {
+	resp = strings.TrimSuffix(strings.TrimPrefix(resp, "\x1b[?"), "c")
+	for _, p := range strings.Split(resp, ";") {
+		if p == "4" {
+			return true
+		}
+	}
+	return false
+}
+
 func runPreload(ctx context.Context, eg *errgroup.Group, cfg config, vInfo videoInfo) {
 	var bar *progressbar.ProgressBar
 	if !cfg.Quiet {
@@ -310,8 +493,405 @@ func runStream(ctx context.Context, eg *errgroup.Group, cfg config, vInfo videoI
 	})
 }
 
-func getVideoInfo(ctx context.Context, videoPath string, targetFPS float64) (videoInfo, error) {
+// runLive wires up extraction, rendering, and playback for an indefinite-length
+// HLS/DASH/network source: frames stream in with no known TotalFrames, and a
+// ring buffer keeps only the last cfg.LiveBufFrames on disk.
+func runLive(ctx context.Context, eg *errgroup.Group, cfg config, vInfo videoInfo) {
+	eg.Go(func() error {
+		return extractFrames(ctx, cfg, vInfo, nil)
+	})
+
+	eg.Go(func() error {
+		runLiveRenderer(ctx, cfg)
+		return nil
+	})
+
+	eg.Go(func() error {
+		evictOldLiveFrames(ctx, cfg.LiveBufFrames)
+		return nil
+	})
+
+	eg.Go(func() error {
+		select {
+		case <-time.After(500 * time.Millisecond):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		return playFrames(ctx, cfg, vInfo)
+	})
+}
+
+// runLiveRenderer renders every jpg frame as it lands on disk, without the
+// fixed totalFrames bound renderFrames expects.
+func runLiveRenderer(ctx context.Context, cfg config) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, cfg.NumThreads)
+	rendered := int64(0)
+
+	for {
+		if ctx.Err() != nil {
+			break
+		}
+		frameNum := int(atomic.LoadInt64(&rendered)) + 1
+		jpgPath := filepath.Join(jpgFramesDir, fmt.Sprintf("frame-%05d.jpg", frameNum))
+		if _, err := os.Stat(jpgPath); err != nil {
+			select {
+			case <-time.After(10 * time.Millisecond):
+				continue
+			case <-ctx.Done():
+				wg.Wait()
+				return
+			}
+		}
+
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				return
+			}
+			_ = renderSingleFrame(ctx, n, false, nil)
+			atomic.StoreInt64(&liveHeadFrame, int64(n))
+		}(frameNum)
+		atomic.AddInt64(&rendered, 1)
+	}
+	wg.Wait()
+}
+
+// evictOldLiveFrames deletes jpg frames that have fallen out of the trailing
+// ringSize window so a long-running live session doesn't fill /dev/shm.
+// Every tick it sweeps everything below the window's new low-water mark
+// rather than a fixed-size slice, so it can't fall behind a write head that
+// advances faster than one tick per evicted frame. Rendered frames are
+// evicted by frameCache itself once they age out of its LRU.
+func evictOldLiveFrames(ctx context.Context, ringSize int) {
+	if ringSize <= 0 {
+		return
+	}
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	evictedBelow := 1
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			oldest := int(atomic.LoadInt64(&liveHeadFrame)) - ringSize
+			for n := evictedBelow; n < oldest; n++ {
+				os.Remove(filepath.Join(jpgFramesDir, fmt.Sprintf("frame-%05d.jpg", n)))
+			}
+			if oldest > evictedBelow {
+				evictedBelow = oldest
+			}
+		}
+	}
+}
+
+// prefetchFrames keeps a window of windowSize frames on either side of
+// state.currentFrame warm in frameCache's memory tier, so seeking by up to
+// cfg.SeekSeconds worth of frames doesn't stall on a disk-tier promotion.
+func prefetchFrames(ctx context.Context, state *playerState, windowSize int) {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			state.Lock()
+			center := state.currentFrame
+			state.Unlock()
+			for n := center - windowSize; n <= center+windowSize; n++ {
+				if n < 1 {
+					continue
+				}
+				frameCache.Warm(n)
+			}
+		}
+	}
+}
+
+// runPipelinePreload and runPipelineStream are the non-legacy counterparts of
+// runPreload/runStream: they decode and render through internal/pipeline
+// instead of the jpgFramesDir/chafaFramesDir tempdir pair.
+
+func runPipelinePreload(ctx context.Context, eg *errgroup.Group, cfg config, vInfo videoInfo) {
+	store := pipeline.NewStore(frameCache)
+	renderAhead := pipelineRenderAheadFrames(cfg)
+	eg.Go(func() error {
+		return pipeline.Run(ctx, cfg.VideoPath, buildVFArg(cfg), cfg.NumThreads, renderAhead, pipelineRenderFunc(cfg), store)
+	})
+	eg.Go(func() error {
+		return playFramesPipeline(ctx, cfg, vInfo, store)
+	})
+}
+
+func runPipelineStream(ctx context.Context, eg *errgroup.Group, cfg config, vInfo videoInfo) {
+	store := pipeline.NewStore(frameCache)
+	renderAhead := pipelineRenderAheadFrames(cfg)
+	eg.Go(func() error {
+		return pipeline.Run(ctx, cfg.VideoPath, buildVFArg(cfg), cfg.NumThreads, renderAhead, pipelineRenderFunc(cfg), store)
+	})
+	eg.Go(func() error {
+		select {
+		case <-time.After(500 * time.Millisecond):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		return playFramesPipeline(ctx, cfg, vInfo, store)
+	})
+}
+
+// pipelineRenderAheadFrames converts pipelineRenderAheadSeconds to a frame
+// count at cfg.FPS, with a small floor so a very low -fps still lets the
+// pipeline keep a couple of frames rendered ahead of the playhead.
+func pipelineRenderAheadFrames(cfg config) int {
+	frames := int(cfg.FPS * pipelineRenderAheadSeconds)
+	if frames < 2 {
+		frames = 2
+	}
+	return frames
+}
+
+// pipelineRenderFunc returns a pipeline.RenderFunc that rasterizes each
+// decoded JPEG frame according to cfg.Protocol: "kitty" and "iterm2" embed it
+// directly as a protocol-specific escape sequence, everything else (plain
+// chafa symbols, and "sixel", which chafa also natively supports) pipes the
+// frame into chafa's stdin (`-`), using the same flags runChafa would pass
+// on the command line.
+func pipelineRenderFunc(cfg config) pipeline.RenderFunc {
+	return func(ctx context.Context, index int, jpegFrame []byte) ([]byte, error) {
+		switch cfg.Protocol {
+		case "kitty":
+			return encodeKittyFrame(jpegFrame, index, cfg.Width, cfg.Height), nil
+		case "iterm2":
+			return encodeIterm2Frame(jpegFrame, cfg.Width, cfg.Height), nil
+		}
+
+		sizeArg := fmt.Sprintf("%dx%d", cfg.Width, cfg.Height)
+		args := []string{"--size", sizeArg, "--colors", cfg.Colors, "--dither", cfg.Dither}
+		if cfg.Protocol == "sixel" {
+			args = append(args, "-f", "sixel")
+		} else {
+			args = append(args, "--symbols", cfg.Symbols)
+		}
+		args = append(args, "-")
+
+		cmd := exec.CommandContext(ctx, "chafa", args...)
+		cmd.Stdin = bytes.NewReader(jpegFrame)
+		var out, stderr bytes.Buffer
+		cmd.Stdout = &out
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, fmt.Errorf("%w: %s", err, stderr.String())
+		}
+		return out.Bytes(), nil
+	}
+}
+
+// playFramesPipeline mirrors playFrames but reads rendered frames through a
+// pipeline.Store (backed by frameCache) instead of polling frameCache itself.
+func playFramesPipeline(ctx context.Context, cfg config, vInfo videoInfo, store *pipeline.Store) error {
+	fmt.Print("\033[?1049h\033[H\033[2J")
+	fmt.Print("\033[?25l")
+	defer func() {
+		fmt.Print("\033[?1049l\033[?25h")
+		stty("sane")
+	}()
+	stty("-echo", "cbreak")
+
+	state := &playerState{
+		playbackSpeeds: []float64{0.25, 0.50, 0.75, 1.00, 1.25, 1.50, 2.00},
+		speedIndex:     3,
+		currentFrame:   1,
+	}
+
+	var audioCtl *audioController
+	if cfg.Audio {
+		audioCtl = newAudioController(ctx, cfg.VideoPath, 0, state.playbackSpeeds[state.speedIndex])
+		defer audioCtl.Close()
+	}
+
+	kbdCtx, cancelKbd := context.WithCancel(ctx)
+	defer cancelKbd()
+	go func() {
+		var buf [3]byte
+		for {
+			select {
+			case <-kbdCtx.Done():
+				return
+			default:
+				n, err := os.Stdin.Read(buf[:])
+				if err != nil || n == 0 {
+					continue
+				}
+
+				key := string(buf[:n])
+				state.Lock()
+				if key == " " {
+					state.paused = !state.paused
+					if audioCtl != nil {
+						audioCtl.SetPaused(state.paused)
+					}
+				} else if key == "\x1b[A" {
+					if state.speedIndex < len(state.playbackSpeeds)-1 {
+						state.speedIndex++
+						if audioCtl != nil {
+							audioCtl.SetSpeed(state.playbackSpeeds[state.speedIndex])
+						}
+					}
+				} else if key == "\x1b[B" {
+					if state.speedIndex > 0 {
+						state.speedIndex--
+						if audioCtl != nil {
+							audioCtl.SetSpeed(state.playbackSpeeds[state.speedIndex])
+						}
+					}
+				} else if key == "\x1b[C" {
+					framesToSeek := int(cfg.FPS * float64(cfg.SeekSeconds))
+					state.currentFrame += framesToSeek
+					if state.currentFrame > vInfo.TotalFrames {
+						state.currentFrame = vInfo.TotalFrames
+					}
+					if audioCtl != nil {
+						audioCtl.Seek(float64(state.currentFrame-1) / cfg.FPS)
+					}
+				} else if key == "\x1b[D" {
+					framesToSeek := int(cfg.FPS * float64(cfg.SeekSeconds))
+					state.currentFrame -= framesToSeek
+					if state.currentFrame < 1 {
+						state.currentFrame = 1
+					}
+					if audioCtl != nil {
+						audioCtl.Seek(float64(state.currentFrame-1) / cfg.FPS)
+					}
+				} else if key == "q" || key == "\x03" {
+					state.quit = true
+				}
+				state.Unlock()
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	// Same reasoning as playFrames: pixel-graphics protocols draw into a
+	// fixed placement rather than scrolling text, so cursor-home alone
+	// leaves stale pixels on screen, and kitty additionally needs its
+	// previous placement deleted by id before the next one is drawn.
+	usesPixelGraphics := cfg.Protocol == "kitty" || cfg.Protocol == "iterm2" || cfg.Protocol == "sixel"
+	lastKittyPlacement := -1
+
+	for {
+		state.Lock()
+		if state.quit {
+			state.Unlock()
+			return context.Canceled
+		}
+
+		if state.paused {
+			drawInfoLine(cfg.Width, cfg.Height+1, "PAUSED", state, vInfo)
+			state.Unlock()
+			time.Sleep(100 * time.Millisecond)
+			continue
+		}
+
+		speed := state.playbackSpeeds[state.speedIndex]
+		frameDelay := time.Duration(1_000_000_000 / (cfg.FPS * speed))
+		ticker.Reset(frameDelay)
+
+		frameToPlay := state.currentFrame
+		state.Unlock()
+
+		if frameToPlay > vInfo.TotalFrames {
+			if cfg.Loop {
+				state.Lock()
+				state.currentFrame = 1
+				state.Unlock()
+				continue
+			}
+			break
+		}
+
+		content, status := store.Get(ctx, frameToPlay)
+		if status == pipeline.FrameEvicted {
+			// Rendered once, but aged out of the bounded cache before
+			// playback reached it (e.g. a backward seek past the render
+			// window). It's gone for good, but later frames aren't -- skip
+			// it instead of ending playback.
+			state.Lock()
+			state.currentFrame = frameToPlay + 1
+			state.Unlock()
+			continue
+		}
+		if status == pipeline.FrameGone {
+			if err := store.Err(); err != nil {
+				return err
+			}
+			return ctx.Err()
+		}
+
+		if usesPixelGraphics {
+			fmt.Print("\033[H\033[2J")
+		} else {
+			fmt.Print("\033[H")
+		}
+		if cfg.Protocol == "kitty" {
+			if lastKittyPlacement >= 0 {
+				fmt.Print(kittyDeleteImage(lastKittyPlacement))
+			}
+			lastKittyPlacement = frameToPlay
+		}
+		fmt.Print(string(content))
+		drawInfoLine(cfg.Width, cfg.Height+1, "Playing", state, vInfo)
+
+		if audioCtl != nil {
+			// Drive playback off the audio master clock instead of a fixed
+			// ticker: jump ahead if rendering fell behind, or wait for the
+			// clock to reach the next frame's timestamp if we're ahead.
+			target := int(audioCtl.Position()*cfg.FPS) + 1
+			state.Lock()
+			if target > frameToPlay+1 {
+				state.currentFrame = target
+			} else {
+				state.currentFrame = frameToPlay + 1
+			}
+			state.Unlock()
+
+			nextFrameTime := float64(frameToPlay) / cfg.FPS
+			for audioCtl.Position() < nextFrameTime {
+				select {
+				case <-time.After(5 * time.Millisecond):
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		} else {
+			state.Lock()
+			state.currentFrame++
+			state.Unlock()
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+
+	return nil
+}
+
+func getVideoInfo(ctx context.Context, videoPath string, targetFPS float64, live bool) (videoInfo, error) {
 	var info videoInfo
+	info.IsLive = live
 
 	cmd := exec.CommandContext(ctx, "ffprobe",
 		"-v", "error",
@@ -326,11 +906,20 @@ func getVideoInfo(ctx context.Context, videoPath string, targetFPS float64) (vid
 	cmd.Stdout = &out
 	cmd.Stderr = &stderr
 	if err := cmd.Run(); err != nil {
+		if live {
+			// Live manifests routinely fail an up-front ffprobe (no duration,
+			// segments not yet published); fall back to defaults and let
+			// extractFrames discover dimensions as it decodes.
+			return info, nil
+		}
 		return info, fmt.Errorf("ffprobe failed: %s\n%s", err, stderr.String())
 	}
 
 	parts := strings.Split(strings.TrimSpace(out.String()), ",")
 	if len(parts) < 5 {
+		if live {
+			return info, nil
+		}
 		return info, fmt.Errorf("unexpected ffprobe output: %s", out.String())
 	}
 
@@ -359,18 +948,24 @@ func getVideoInfo(ctx context.Context, videoPath string, targetFPS float64) (vid
 	} else if info.NbFramesStream > 0 && info.AvgFrameRate > 0 {
 		estimatedDuration := float64(info.NbFramesStream) / info.AvgFrameRate
 		info.TotalFrames = int(math.Ceil(estimatedDuration * targetFPS))
+	} else if live {
+		// Unknown-length live source: TotalFrames stays 0 and playFrames
+		// switches to write-head-relative playback instead of a percentage.
+		return info, nil
 	} else {
 		return info, errors.New("could not determine video duration or frame count")
 	}
 
-	if info.TotalFrames <= 0 {
+	if info.TotalFrames <= 0 && !live {
 		return info, errors.New("calculated total frames is zero or less")
 	}
 
 	return info, nil
 }
 
-func extractFrames(ctx context.Context, cfg config, vInfo videoInfo, bar *progressbar.ProgressBar) error {
+// buildVFArg constructs the ffmpeg -vf chain (fps + scale/crop) shared by
+// every extraction path.
+func buildVFArg(cfg config) string {
 	pixelWidth := cfg.Width * charPixelWidthApprox
 	pixelHeight := cfg.Height * charPixelHeightApprox
 
@@ -384,7 +979,183 @@ func extractFrames(ctx context.Context, cfg config, vInfo videoInfo, bar *progre
 		scaleVf = fmt.Sprintf("scale=%d:%d", pixelWidth, pixelHeight)
 	}
 
-	vfArg := fmt.Sprintf("fps=%.2f,%s", cfg.FPS, scaleVf)
+	return fmt.Sprintf("fps=%.2f,%s", cfg.FPS, scaleVf)
+}
+
+// planAdaptiveSampling collects scene-cut timestamps (from ffmpeg's
+// scene-change filter, or from -scenes-csv) and turns them into the
+// frame-by-frame timestamp plan extractFramesAdaptive will seek through:
+// dense (max-fps) sampling within 0.5s of a cut, sparse (min-fps) sampling
+// everywhere else.
+func planAdaptiveSampling(ctx context.Context, cfg config, vInfo videoInfo) (videoInfo, error) {
+	var cuts []float64
+	var err error
+	if cfg.ScenesCSV != "" {
+		cuts, err = readScenesCSV(cfg.ScenesCSV)
+	} else {
+		cuts, err = detectSceneCuts(ctx, cfg.VideoPath, cfg.SceneThresh)
+	}
+	if err != nil {
+		return vInfo, err
+	}
+
+	vInfo.FrameTimestamps = buildAdaptiveTimestamps(cuts, vInfo.Duration, cfg.MinFPS, cfg.MaxFPS)
+	vInfo.TotalFrames = len(vInfo.FrameTimestamps)
+	if vInfo.TotalFrames == 0 {
+		return vInfo, errors.New("adaptive sampling produced zero frames")
+	}
+	return vInfo, nil
+}
+
+// detectSceneCuts runs a first ffmpeg pass with the scene-change select
+// filter and showinfo, and parses the pts_time of each detected cut from
+// ffmpeg's stderr.
+func detectSceneCuts(ctx context.Context, videoPath string, threshold float64) ([]float64, error) {
+	vf := fmt.Sprintf("select='gt(scene,%.3f)',showinfo", threshold)
+	cmd := exec.CommandContext(ctx, "ffmpeg",
+		"-nostdin", "-loglevel", "info",
+		"-i", videoPath,
+		"-vf", vf,
+		"-f", "null", "-",
+	)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("scene detection pass failed: %w\n%s", err, stderr.String())
+	}
+
+	ptsRe := regexp.MustCompile(`pts_time:([0-9.]+)`)
+	var cuts []float64
+	scanner := bufio.NewScanner(&stderr)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.Contains(line, "Parsed_showinfo") {
+			continue
+		}
+		m := ptsRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		if pts, err := strconv.ParseFloat(m[1], 64); err == nil {
+			cuts = append(cuts, pts)
+		}
+	}
+	return cuts, nil
+}
+
+// readScenesCSV reads a one-timestamp-per-line (or per-field) CSV of
+// precomputed scene-cut seconds, for users who already ran their own
+// detection.
+func readScenesCSV(path string) ([]float64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open -scenes-csv: %w", err)
+	}
+	defer f.Close()
+
+	var cuts []float64
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		for _, field := range strings.Split(scanner.Text(), ",") {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+			v, err := strconv.ParseFloat(field, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid timestamp %q in -scenes-csv: %w", field, err)
+			}
+			cuts = append(cuts, v)
+		}
+	}
+	return cuts, scanner.Err()
+}
+
+// buildAdaptiveTimestamps merges min-fps background sampling with max-fps
+// sampling in a 1-second window (±0.5s) around every cut, and returns the
+// sorted, de-duplicated frame timestamps.
+func buildAdaptiveTimestamps(cuts []float64, duration, minFPS, maxFPS float64) []float64 {
+	const cutWindow = 0.5
+	isNearCut := func(t float64) bool {
+		for _, c := range cuts {
+			if math.Abs(t-c) <= cutWindow {
+				return true
+			}
+		}
+		return false
+	}
+
+	seen := make(map[int]bool) // dedupe on millisecond granularity
+	var timestamps []float64
+	add := func(t float64) {
+		if t < 0 || t > duration {
+			return
+		}
+		key := int(t * 1000)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		timestamps = append(timestamps, t)
+	}
+
+	for _, c := range cuts {
+		for t := c - cutWindow; t <= c+cutWindow; t += 1 / maxFPS {
+			add(t)
+		}
+	}
+	for t := 0.0; t < duration; t += 1 / minFPS {
+		if !isNearCut(t) {
+			add(t)
+		}
+	}
+
+	sort.Float64s(timestamps)
+	return timestamps
+}
+
+// extractFramesAdaptive pulls one frame per planned timestamp in a single
+// ffmpeg process: the input is opened and decoded once, and each timestamp
+// becomes its own "-ss <t> -frames:v 1 <output>" output attached to that one
+// input, which ffmpeg seeks to accurately (unlike a per-frame "-ss" placed
+// before "-i", which only lands on the nearest keyframe). That keeps scene
+// cuts frame-accurate without spawning one ffmpeg per sample.
+func extractFramesAdaptive(ctx context.Context, cfg config, vInfo videoInfo, bar *progressbar.ProgressBar) error {
+	vfArg := strings.TrimPrefix(buildVFArg(cfg), fmt.Sprintf("fps=%.2f,", cfg.FPS))
+
+	args := []string{"-nostdin", "-loglevel", "error", "-i", cfg.VideoPath}
+	for i, ts := range vInfo.FrameTimestamps {
+		outputPath := filepath.Join(jpgFramesDir, fmt.Sprintf("frame-%05d.jpg", i+1))
+		args = append(args,
+			"-ss", fmt.Sprintf("%.3f", ts),
+			"-vf", vfArg,
+			"-frames:v", "1",
+			"-q:v", "2",
+			outputPath,
+		)
+	}
+
+	cmd := exec.CommandContext(ctx, "ffmpeg", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("ffmpeg seek-extract failed: %w\n%s", err, stderr.String())
+	}
+	if bar != nil {
+		bar.Set(len(vInfo.FrameTimestamps))
+		bar.Finish()
+	}
+	return nil
+}
+
+func extractFrames(ctx context.Context, cfg config, vInfo videoInfo, bar *progressbar.ProgressBar) error {
+	if cfg.Adaptive {
+		return extractFramesAdaptive(ctx, cfg, vInfo, bar)
+	}
+	vfArg := buildVFArg(cfg)
 	outputPath := filepath.Join(jpgFramesDir, "frame-%05d.jpg")
 
 	args := []string{
@@ -485,7 +1256,6 @@ func renderFrames(ctx context.Context, eg *errgroup.Group, numWorkers, totalFram
 
 func renderSingleFrame(ctx context.Context, frameNum int, isPreload bool, bar *progressbar.ProgressBar) error {
 	jpgPath := filepath.Join(jpgFramesDir, fmt.Sprintf("frame-%05d.jpg", frameNum))
-	txtPath := filepath.Join(chafaFramesDir, fmt.Sprintf("frame-%05d.txt", frameNum))
 
 	if !isPreload {
 		for {
@@ -503,9 +1273,16 @@ func renderSingleFrame(ctx context.Context, frameNum int, isPreload bool, bar *p
 	if _, err := os.Stat(jpgPath); err == nil {
 		w := flag.Lookup("width").Value.(flag.Getter).Get().(int)
 		h := flag.Lookup("height").Value.(flag.Getter).Get().(int)
-		err := runChafa(ctx, jpgPath, txtPath, w, h)
-		if err != nil && ctx.Err() == nil {
-			fmt.Fprintf(os.Stderr, "Chafa failed for frame %d: %v\n", frameNum, err)
+		protocol := flag.Lookup("protocol").Value.String()
+		rendered, err := renderFrame(ctx, protocol, jpgPath, frameNum, w, h)
+		if err != nil {
+			if ctx.Err() == nil {
+				fmt.Fprintf(os.Stderr, "Render failed for frame %d: %v\n", frameNum, err)
+			}
+			return err
+		}
+		if err := frameCache.Put(frameNum, rendered); err != nil {
+			fmt.Fprintf(os.Stderr, "Cache write failed for frame %d: %v\n", frameNum, err)
 			return err
 		}
 		if bar != nil {
@@ -515,39 +1292,101 @@ func renderSingleFrame(ctx context.Context, frameNum int, isPreload bool, bar *p
 	return nil
 }
 
-func runChafa(ctx context.Context, inputPath, outputPath string, width, height int) error {
+// renderFrame rasterizes inputPath using the given graphics protocol.
+// "chafa" and "sixel" both shell out to chafa (which natively supports a
+// sixel output format); "kitty" and "iterm2" embed the JPEG directly as a
+// protocol-specific escape sequence, bypassing chafa's character/sixel
+// quantization entirely.
+func renderFrame(ctx context.Context, protocol, inputPath string, frameNum, width, height int) ([]byte, error) {
+	switch protocol {
+	case "sixel":
+		return runChafa(ctx, inputPath, width, height, "sixel")
+	case "kitty":
+		raw, err := os.ReadFile(inputPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", inputPath, err)
+		}
+		return encodeKittyFrame(raw, frameNum, width, height), nil
+	case "iterm2":
+		raw, err := os.ReadFile(inputPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", inputPath, err)
+		}
+		return encodeIterm2Frame(raw, width, height), nil
+	default:
+		return runChafa(ctx, inputPath, width, height, "symbols")
+	}
+}
+
+func runChafa(ctx context.Context, inputPath string, width, height int, format string) ([]byte, error) {
 	colors := flag.Lookup("colors").Value.String()
 	dither := flag.Lookup("dither").Value.String()
 	symbols := flag.Lookup("symbols").Value.String()
 
 	sizeArg := fmt.Sprintf("%dx%d", width, height)
 
-	args := []string{
-		"--size", sizeArg,
-		"--colors", colors,
-		"--dither", dither,
-		"--symbols", symbols,
-		inputPath,
+	args := []string{"--size", sizeArg, "--colors", colors, "--dither", dither}
+	if format == "sixel" {
+		args = append(args, "-f", "sixel")
+	} else {
+		args = append(args, "--symbols", symbols)
 	}
+	args = append(args, inputPath)
 
 	cmd := exec.CommandContext(ctx, "chafa", args...)
-	var stderr bytes.Buffer
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
-	outfile, err := os.Create(outputPath)
-	if err != nil {
-		return fmt.Errorf("failed to create chafa output file: %w", err)
-	}
-	defer outfile.Close()
-	cmd.Stdout = outfile
-
 	if err := cmd.Run(); err != nil {
 		if ctx.Err() != nil {
-			return ctx.Err()
+			return nil, ctx.Err()
 		}
-		return fmt.Errorf("%w: %s", err, stderr.String())
+		return nil, fmt.Errorf("%w: %s", err, stderr.String())
 	}
-	return nil
+	return stdout.Bytes(), nil
+}
+
+const kittyChunkSize = 4096
+
+// encodeKittyFrame base64-encodes jpeg into a chunked Kitty graphics-protocol
+// APC transmit-and-display command, sized to width x height terminal cells.
+// frameNum becomes the image placement id so the player can delete it once
+// the next frame is on screen.
+func encodeKittyFrame(jpeg []byte, frameNum, width, height int) []byte {
+	encoded := base64.StdEncoding.EncodeToString(jpeg)
+
+	var buf bytes.Buffer
+	for len(encoded) > 0 {
+		chunk := encoded
+		if len(chunk) > kittyChunkSize {
+			chunk = encoded[:kittyChunkSize]
+		}
+		encoded = encoded[len(chunk):]
+		more := 0
+		if len(encoded) > 0 {
+			more = 1
+		}
+		if buf.Len() == 0 {
+			fmt.Fprintf(&buf, "\x1b_Ga=T,f=100,t=d,i=%d,c=%d,r=%d,m=%d;%s\x1b\\", frameNum, width, height, more, chunk)
+		} else {
+			fmt.Fprintf(&buf, "\x1b_Gm=%d;%s\x1b\\", more, chunk)
+		}
+	}
+	return buf.Bytes()
+}
+
+// encodeIterm2Frame wraps jpeg in iTerm2's inline-image escape sequence,
+// sized to width x height terminal cells.
+func encodeIterm2Frame(jpeg []byte, width, height int) []byte {
+	encoded := base64.StdEncoding.EncodeToString(jpeg)
+	return []byte(fmt.Sprintf("\x1b]1337;File=inline=1;width=%d;height=%d:%s\a", width, height, encoded))
+}
+
+// kittyDeleteImage returns the APC sequence that deletes a previously drawn
+// Kitty graphics placement by id.
+func kittyDeleteImage(id int) string {
+	return fmt.Sprintf("\x1b_Ga=d,d=I,i=%d\x1b\\", id)
 }
 
 func playFrames(ctx context.Context, cfg config, vInfo videoInfo) error {
@@ -565,6 +1404,15 @@ func playFrames(ctx context.Context, cfg config, vInfo videoInfo) error {
 		currentFrame:   1,
 	}
 
+	var audioCtl *audioController
+	if cfg.Audio && !vInfo.IsLive {
+		audioCtl = newAudioController(ctx, cfg.VideoPath, 0, state.playbackSpeeds[state.speedIndex])
+		defer audioCtl.Close()
+	}
+
+	prefetchWindow := int(cfg.FPS * float64(cfg.SeekSeconds))
+	go prefetchFrames(ctx, state, prefetchWindow)
+
 	kbdCtx, cancelKbd := context.WithCancel(ctx)
 	defer cancelKbd()
 	go func() {
@@ -583,26 +1431,45 @@ func playFrames(ctx context.Context, cfg config, vInfo videoInfo) error {
 				state.Lock()
 				if key == " " {
 					state.paused = !state.paused
+					if audioCtl != nil {
+						audioCtl.SetPaused(state.paused)
+					}
 				} else if key == "\x1b[A" {
 					if state.speedIndex < len(state.playbackSpeeds)-1 {
 						state.speedIndex++
+						if audioCtl != nil {
+							audioCtl.SetSpeed(state.playbackSpeeds[state.speedIndex])
+						}
 					}
 				} else if key == "\x1b[B" {
 					if state.speedIndex > 0 {
 						state.speedIndex--
+						if audioCtl != nil {
+							audioCtl.SetSpeed(state.playbackSpeeds[state.speedIndex])
+						}
 					}
 				} else if key == "\x1b[C" {
 					framesToSeek := int(cfg.FPS * float64(cfg.SeekSeconds))
 					state.currentFrame += framesToSeek
-					if state.currentFrame > vInfo.TotalFrames {
+					if vInfo.IsLive {
+						if head := int(atomic.LoadInt64(&liveHeadFrame)); state.currentFrame > head {
+							state.currentFrame = head
+						}
+					} else if state.currentFrame > vInfo.TotalFrames {
 						state.currentFrame = vInfo.TotalFrames
 					}
+					if audioCtl != nil {
+						audioCtl.Seek(float64(state.currentFrame-1) / cfg.FPS)
+					}
 				} else if key == "\x1b[D" {
 					framesToSeek := int(cfg.FPS * float64(cfg.SeekSeconds))
 					state.currentFrame -= framesToSeek
 					if state.currentFrame < 1 {
 						state.currentFrame = 1
 					}
+					if audioCtl != nil {
+						audioCtl.Seek(float64(state.currentFrame-1) / cfg.FPS)
+					}
 				} else if key == "q" || key == "\x03" {
 					state.quit = true
 				}
@@ -614,6 +1481,13 @@ func playFrames(ctx context.Context, cfg config, vInfo videoInfo) error {
 	ticker := time.NewTicker(time.Second)
 	defer ticker.Stop()
 
+	// Pixel-graphics protocols draw into a fixed placement rather than
+	// scrolling text, so cursor-home alone leaves stale pixels on screen;
+	// kitty additionally needs its previous placement deleted by id before
+	// the next one is drawn.
+	usesPixelGraphics := cfg.Protocol == "kitty" || cfg.Protocol == "iterm2" || cfg.Protocol == "sixel"
+	lastKittyPlacement := -1
+
 	for {
 		state.Lock()
 		if state.quit {
@@ -630,12 +1504,18 @@ func playFrames(ctx context.Context, cfg config, vInfo videoInfo) error {
 
 		speed := state.playbackSpeeds[state.speedIndex]
 		frameDelay := time.Duration(1_000_000_000 / (cfg.FPS * speed))
+		if cfg.Adaptive && state.currentFrame >= 1 && state.currentFrame < len(vInfo.FrameTimestamps) {
+			// Respect the variable gaps the adaptive sampler left between
+			// frames instead of the fixed -fps delay.
+			gap := vInfo.FrameTimestamps[state.currentFrame] - vInfo.FrameTimestamps[state.currentFrame-1]
+			frameDelay = time.Duration(gap / speed * float64(time.Second))
+		}
 		ticker.Reset(frameDelay)
 
 		frameToPlay := state.currentFrame
 		state.Unlock()
 
-		if frameToPlay > vInfo.TotalFrames {
+		if !vInfo.IsLive && frameToPlay > vInfo.TotalFrames {
 			if cfg.Loop {
 				state.Lock()
 				state.currentFrame = 1
@@ -645,10 +1525,18 @@ func playFrames(ctx context.Context, cfg config, vInfo videoInfo) error {
 			break
 		}
 
-		txtPath := filepath.Join(chafaFramesDir, fmt.Sprintf("frame-%05d.txt", frameToPlay))
-
+		var content []byte
+		var have bool
 		for {
-			if _, err := os.Stat(txtPath); err == nil {
+			if content, have = frameCache.Get(frameToPlay); have {
+				break
+			}
+			if vInfo.IsLive && frameToPlay < int(atomic.LoadInt64(&liveHeadFrame))-cfg.LiveBufFrames {
+				// This frame fell out of the ring buffer before we could
+				// play it; skip ahead to the current write head.
+				state.Lock()
+				state.currentFrame = int(atomic.LoadInt64(&liveHeadFrame))
+				state.Unlock()
 				break
 			}
 			select {
@@ -658,21 +1546,56 @@ func playFrames(ctx context.Context, cfg config, vInfo videoInfo) error {
 			}
 		}
 
-		content, err := os.ReadFile(txtPath)
-		if err == nil {
-			fmt.Print("\033[H", string(content))
+		if !have {
+			continue
 		}
 
+		if usesPixelGraphics {
+			fmt.Print("\033[H\033[2J")
+		} else {
+			fmt.Print("\033[H")
+		}
+		if cfg.Protocol == "kitty" {
+			if lastKittyPlacement >= 0 {
+				fmt.Print(kittyDeleteImage(lastKittyPlacement))
+			}
+			lastKittyPlacement = frameToPlay
+		}
+		fmt.Print(string(content))
+
 		drawInfoLine(cfg.Width, cfg.Height+1, "Playing", state, vInfo)
 
-		state.Lock()
-		state.currentFrame++
-		state.Unlock()
+		if audioCtl != nil {
+			// Drive playback off the audio master clock instead of a fixed
+			// ticker: jump ahead if rendering fell behind, or wait for the
+			// clock to reach the next frame's timestamp if we're ahead.
+			target := int(audioCtl.Position()*cfg.FPS) + 1
+			state.Lock()
+			if target > frameToPlay+1 {
+				state.currentFrame = target
+			} else {
+				state.currentFrame = frameToPlay + 1
+			}
+			state.Unlock()
 
-		select {
-		case <-ticker.C:
-		case <-ctx.Done():
-			return ctx.Err()
+			nextFrameTime := float64(frameToPlay) / cfg.FPS
+			for audioCtl.Position() < nextFrameTime {
+				select {
+				case <-time.After(5 * time.Millisecond):
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		} else {
+			state.Lock()
+			state.currentFrame++
+			state.Unlock()
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
 		}
 	}
 
@@ -682,14 +1605,191 @@ func playFrames(ctx context.Context, cfg config, vInfo videoInfo) error {
 func drawInfoLine(width, row int, status string, state *playerState, vInfo videoInfo) {
 	fmt.Printf("\033[s\033[%d;0H\033[K", row)
 
-	percent := 0
-	if vInfo.TotalFrames > 0 {
-		percent = (state.currentFrame * 100) / vInfo.TotalFrames
+	var info string
+	if vInfo.IsLive {
+		behind := int(atomic.LoadInt64(&liveHeadFrame)) - state.currentFrame
+		info = fmt.Sprintf("[LIVE %s] frame %d | -%d behind head | Speed: %.2fx | q/Ctrl+C to quit",
+			status, state.currentFrame, behind, state.playbackSpeeds[state.speedIndex])
+	} else {
+		percent := 0
+		if vInfo.TotalFrames > 0 {
+			percent = (state.currentFrame * 100) / vInfo.TotalFrames
+		}
+		info = fmt.Sprintf("[%s] %d/%d (%d%%) | Speed: %.2fx | q/Ctrl+C to quit",
+			status, state.currentFrame, vInfo.TotalFrames, percent, state.playbackSpeeds[state.speedIndex])
 	}
-	info := fmt.Sprintf("[%s] %d/%d (%d%%) | Speed: %.2fx | q/Ctrl+C to quit",
-		status, state.currentFrame, vInfo.TotalFrames, percent, state.playbackSpeeds[state.speedIndex])
 
 	fmt.Print(info)
 
 	fmt.Print("\033[u")
 }
+
+// audioController owns a single ffmpeg-decode-to-PCM process piped into an
+// ALSA sink (aplay), and exposes the "master clock" (seconds of audio
+// played, accounting for pauses, seeks, and atempo speed changes) that
+// playFrames paces video frames against when -audio is set.
+//
+// There's no Go audio library (oto/malgo/beep) vendored in this tree, so the
+// PCM is never decoded or buffered here; ffmpeg's stdout is copied into
+// aplay's stdin through an in-process io.Pipe. The clock is therefore a
+// wall-clock estimate
+// anchored at each (re)start rather than a literal played-sample count.
+type audioController struct {
+	ctx       context.Context
+	videoPath string
+
+	mu        sync.Mutex
+	position  float64 // seconds into the track as of startWall
+	startWall time.Time
+	speed     float64
+	paused    bool
+
+	ffmpeg *exec.Cmd
+	sink   *exec.Cmd
+}
+
+// newAudioController starts audio playback at startSeconds and returns the
+// controller. Call Close when done to tear down the subprocesses.
+func newAudioController(ctx context.Context, videoPath string, startSeconds, speed float64) *audioController {
+	a := &audioController{ctx: ctx, videoPath: videoPath, position: startSeconds, speed: speed, startWall: time.Now()}
+	a.spawn(startSeconds)
+	return a
+}
+
+// Position returns the current master-clock position in seconds.
+func (a *audioController) Position() float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.paused {
+		return a.position
+	}
+	return a.position + time.Since(a.startWall).Seconds()*a.speed
+}
+
+// SetPaused pauses or resumes the audio stream, preserving position.
+func (a *audioController) SetPaused(paused bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if paused == a.paused {
+		return
+	}
+	if paused {
+		a.position += time.Since(a.startWall).Seconds() * a.speed
+		a.killLocked()
+	} else {
+		a.startWall = time.Now()
+		a.spawnLocked(a.position)
+	}
+	a.paused = paused
+}
+
+// Seek restarts audio decode at an absolute position.
+func (a *audioController) Seek(seconds float64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if seconds < 0 {
+		seconds = 0
+	}
+	a.position = seconds
+	a.startWall = time.Now()
+	if !a.paused {
+		a.killLocked()
+		a.spawnLocked(seconds)
+	}
+}
+
+// SetSpeed changes the atempo applied to future audio, restarting decode
+// from the current position so pitch stays reasonable up to 2x.
+func (a *audioController) SetSpeed(speed float64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.paused {
+		a.position += time.Since(a.startWall).Seconds() * a.speed
+		a.startWall = time.Now()
+	}
+	a.speed = speed
+	if !a.paused {
+		a.killLocked()
+		a.spawnLocked(a.position)
+	}
+}
+
+// Close tears down the ffmpeg/aplay pair.
+func (a *audioController) Close() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.killLocked()
+}
+
+func (a *audioController) spawn(atSeconds float64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.spawnLocked(atSeconds)
+}
+
+func (a *audioController) spawnLocked(atSeconds float64) {
+	ffArgs := []string{"-nostdin", "-loglevel", "error"}
+	if atSeconds > 0 {
+		ffArgs = append(ffArgs, "-ss", fmt.Sprintf("%.3f", atSeconds))
+	}
+	ffArgs = append(ffArgs, "-i", a.videoPath, "-vn")
+	if a.speed != 1.0 {
+		ffArgs = append(ffArgs, "-af", atempoChain(a.speed))
+	}
+	ffArgs = append(ffArgs, "-f", "s16le", "-ac", "2", "-ar", "48000", "pipe:1")
+
+	ffCmd := exec.CommandContext(a.ctx, "ffmpeg", ffArgs...)
+	sinkCmd := exec.CommandContext(a.ctx, "aplay", "-q", "-f", "S16_LE", "-r", "48000", "-c", "2", "-")
+
+	pr, pw := io.Pipe()
+	ffCmd.Stdout = pw
+	sinkCmd.Stdin = pr
+
+	if err := sinkCmd.Start(); err != nil {
+		fmt.Fprintln(os.Stderr, "Warning: failed to start audio sink:", err)
+		return
+	}
+	if err := ffCmd.Start(); err != nil {
+		fmt.Fprintln(os.Stderr, "Warning: failed to start audio decode:", err)
+		sinkCmd.Process.Kill()
+		return
+	}
+	go func() {
+		ffCmd.Wait()
+		pw.Close()
+	}()
+
+	a.ffmpeg = ffCmd
+	a.sink = sinkCmd
+}
+
+func (a *audioController) killLocked() {
+	if a.ffmpeg != nil && a.ffmpeg.Process != nil {
+		a.ffmpeg.Process.Kill()
+		a.ffmpeg.Wait()
+	}
+	if a.sink != nil && a.sink.Process != nil {
+		a.sink.Process.Kill()
+		a.sink.Wait()
+	}
+	a.ffmpeg, a.sink = nil, nil
+}
+
+// atempoChain returns an ffmpeg -af value that reaches speed exactly, even
+// though a single atempo filter only accepts the 0.5-2.0 range: speeds
+// outside it are built by chaining multiple atempo stages (e.g. 0.25x is
+// atempo=0.5,atempo=0.5), rather than clamping to the nearest end of the
+// range and letting audio and video drift out of sync.
+func atempoChain(speed float64) string {
+	var stages []string
+	for speed < 0.5 {
+		stages = append(stages, "atempo=0.5")
+		speed /= 0.5
+	}
+	for speed > 2.0 {
+		stages = append(stages, "atempo=2.0")
+		speed /= 2.0
+	}
+	stages = append(stages, fmt.Sprintf("atempo=%.3f", speed))
+	return strings.Join(stages, ",")
+}